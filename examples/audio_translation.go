@@ -31,7 +31,10 @@ func main() {
 	accessKeySecret := os.Getenv("LARA_ACCESS_KEY_SECRET")
 
 	credentials := lara.NewAccessKey(accessKeyID, accessKeySecret)
-	translator := lara.NewTranslator(credentials, nil)
+	translator, err := lara.NewTranslator(credentials, nil)
+	if err != nil {
+		log.Fatalf("Failed to create translator: %v", err)
+	}
 
 	// Replace with your actual audio file path
 	sampleFilePath := "sample_audio.mp3" // Supported: .wav, .mp3, .opus, .ogg, .webm
@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"io"
+	"log"
 	"os"
 	"time"
 
@@ -27,7 +28,10 @@ func main() {
 	accessKeyID := os.Getenv("LARA_ACCESS_KEY_ID")
 	accessKeySecret := os.Getenv("LARA_ACCESS_KEY_SECRET")
 
-	translator := lara.NewTranslator(lara.NewCredentials(accessKeyID, accessKeySecret), nil)
+	translator, err := lara.NewTranslator(lara.NewCredentials(accessKeyID, accessKeySecret), nil)
+	if err != nil {
+		log.Fatalf("Failed to create translator: %v", err)
+	}
 
 	// Replace with your actual document file path
 	sampleFilePath := "sample_document.docx" // Create this file with your content
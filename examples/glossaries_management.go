@@ -32,14 +32,17 @@ func main() {
 	accessKeyID := os.Getenv("LARA_ACCESS_KEY_ID")
 	accessKeySecret := os.Getenv("LARA_ACCESS_KEY_SECRET")
 
-	laraTranslator := lara.NewTranslator(lara.NewCredentials(accessKeyID, accessKeySecret), nil)
+	laraTranslator, err := lara.NewTranslator(lara.NewCredentials(accessKeyID, accessKeySecret), nil)
+	if err != nil {
+		log.Fatalf("Failed to create translator: %v", err)
+	}
 
 	fmt.Println("🗒️  Glossaries require a specific subscription plan.")
 	fmt.Println("   If you encounter errors, please check your subscription level.\n")
 
 	var glossaryID string
 
-	err := func() error {
+	err = func() error {
 		// Example 1: Basic glossary management
 		fmt.Println("=== Basic Glossary Management ===")
 		glossary, err := laraTranslator.Glossaries.Create("MyDemoGlossary")
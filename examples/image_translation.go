@@ -26,7 +26,10 @@ func main() {
 	accessKeyID := os.Getenv("LARA_ACCESS_KEY_ID")
 	accessKeySecret := os.Getenv("LARA_ACCESS_KEY_SECRET")
 
-	laraTranslator := lara.NewTranslator(lara.NewCredentials(accessKeyID, accessKeySecret), nil)
+	laraTranslator, err := lara.NewTranslator(lara.NewCredentials(accessKeyID, accessKeySecret), nil)
+	if err != nil {
+		log.Fatalf("Failed to create translator: %v", err)
+	}
 
 	// Replace with your actual image file path
 	sampleFilePath := filepath.Join(".", "sample_image.png")
@@ -43,28 +46,29 @@ func main() {
 	fmt.Println("=== Basic Image Translation ===")
 	fmt.Printf("Translating image: %s from %s to %s\n", filepath.Base(sampleFilePath), sourceLang, targetLang)
 
-	imageBytes, err := laraTranslator.Images.Translate(&sampleFilePath, &sourceLang, targetLang)
+	translated, err := laraTranslator.Images.Translate(&sampleFilePath, &sourceLang, targetLang)
 	if err != nil {
 		log.Printf("Error translating image: %v", err)
 		return
 	}
 
-	outputPath := filepath.Join(".", "sample_image_translated.png")
-	if err := os.WriteFile(outputPath, imageBytes, 0644); err != nil {
+	outputPath := filepath.Join(".", "sample_image_translated"+translated.Extension())
+	if err := os.WriteFile(outputPath, translated.Data, 0644); err != nil {
 		log.Printf("Error saving translated image: %v", err)
 		return
 	}
 	fmt.Println("Image translation completed")
-	fmt.Printf("Translated image saved to: %s\n\n", filepath.Base(outputPath))
+	fmt.Printf("Translated image (%s, %dx%d) saved to: %s\n\n", translated.ContentType, translated.Width, translated.Height, filepath.Base(outputPath))
 
 	// Example 2: Image translation with advanced options
 	fmt.Println("=== Image Translation with Advanced Options ===")
 
-	advancedBytes, err := laraTranslator.Images.TranslateWithOptions(&sampleFilePath, &sourceLang, targetLang, &lara.ImageTranslateOptions{
-		AdaptTo:    []string{"mem_1A2b3C4d5E6f7G8h9I0jKl"}, // Replace with actual memory IDs
-		Glossaries: []string{"gls_1A2b3C4d5E6f7G8h9I0jKl"}, // Replace with actual glossary IDs
-		Style:      lara.TranslationStyleFaithful,
-		Model:      lara.TextRemovalInpainting,
+	advanced, err := laraTranslator.Images.TranslateWithOptions(&sampleFilePath, &sourceLang, targetLang, &lara.ImageTranslateOptions{
+		AdaptTo:      []string{"mem_1A2b3C4d5E6f7G8h9I0jKl"}, // Replace with actual memory IDs
+		Glossaries:   []string{"gls_1A2b3C4d5E6f7G8h9I0jKl"}, // Replace with actual glossary IDs
+		Style:        lara.TranslationStyleFaithful,
+		Model:        lara.TextRemovalInpainting,
+		OutputFormat: lara.ImageFormatPng,
 	})
 	if err != nil {
 		log.Printf("Error in advanced translation: %v", err)
@@ -72,7 +76,7 @@ func main() {
 	}
 
 	advancedOutputPath := filepath.Join(".", "advanced_image_translated.png")
-	if err := os.WriteFile(advancedOutputPath, advancedBytes, 0644); err != nil {
+	if err := os.WriteFile(advancedOutputPath, advanced.Data, 0644); err != nil {
 		log.Printf("Error saving translated image: %v", err)
 		return
 	}
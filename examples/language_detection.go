@@ -27,7 +27,10 @@ func main() {
 	accessKeyID := os.Getenv("LARA_ACCESS_KEY_ID")
 	accessKeySecret := os.Getenv("LARA_ACCESS_KEY_SECRET")
 
-	laraTranslator := lara.NewTranslator(lara.NewCredentials(accessKeyID, accessKeySecret), nil)
+	laraTranslator, err := lara.NewTranslator(lara.NewCredentials(accessKeyID, accessKeySecret), nil)
+	if err != nil {
+		log.Fatalf("Failed to create translator: %v", err)
+	}
 
 	// Example 1: Basic language detection from a single string
 	fmt.Println("=== Basic Language Detection ===")
@@ -30,7 +30,10 @@ func main() {
 	accessKeyID := os.Getenv("LARA_ACCESS_KEY_ID")
 	accessKeySecret := os.Getenv("LARA_ACCESS_KEY_SECRET")
 
-	laraTranslator := lara.NewTranslator(lara.NewCredentials(accessKeyID, accessKeySecret), nil)
+	laraTranslator, err := lara.NewTranslator(lara.NewCredentials(accessKeyID, accessKeySecret), nil)
+	if err != nil {
+		log.Fatalf("Failed to create translator: %v", err)
+	}
 
 	var memory2 *lara.Memory
 
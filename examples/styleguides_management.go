@@ -26,14 +26,17 @@ func main() {
 	accessKeyID := os.Getenv("LARA_ACCESS_KEY_ID")
 	accessKeySecret := os.Getenv("LARA_ACCESS_KEY_SECRET")
 
-	laraTranslator := lara.NewTranslator(lara.NewCredentials(accessKeyID, accessKeySecret), nil)
+	laraTranslator, err := lara.NewTranslator(lara.NewCredentials(accessKeyID, accessKeySecret), nil)
+	if err != nil {
+		log.Fatalf("Failed to create translator: %v", err)
+	}
 
 	fmt.Println("📋 Styleguides require a specific subscription plan.")
 	fmt.Println("   If you encounter errors, please check your subscription level.\n")
 
 	var styleguideID string
 
-	err := func() error {
+	err = func() error {
 		// Example 1: Basic styleguide management
 		fmt.Println("=== Basic Styleguide Management ===")
 		styleguide, err := laraTranslator.Styleguides.Create("MyDemoStyleguide", "Use a formal tone. Prefer British English spelling. Avoid contractions.")
@@ -0,0 +1,49 @@
+package lara
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// writeFileAtomic writes r to a temporary file next to path and renames it into
+// place, so that path never holds a partial file.
+func writeFileAtomic(path string, r io.Reader) error {
+	tmp, err := createTempNextTo(path, ".tmp")
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	return replaceFile(tmp, path)
+}
+
+// createTempNextTo creates a hidden temporary file in the directory of path,
+// creating the directory if needed.
+func createTempNextTo(path, suffix string) (*os.File, error) {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return os.CreateTemp(dir, "."+filepath.Base(path)+".*"+suffix)
+}
+
+// replaceFile flushes and closes the completed file tmp and renames it to
+// path. tmp is removed if it cannot be moved into place.
+func replaceFile(tmp *os.File, path string) error {
+	err := tmp.Sync()
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+	return err
+}
@@ -1,8 +1,12 @@
 package lara
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
+	"os"
+	"sync"
 	"time"
 )
 
@@ -20,60 +24,161 @@ func newAudioTranslator(client *Client, s3Client *S3Client) *AudioTranslator {
 
 // Upload uploads an audio file and creates a translation job
 func (a *AudioTranslator) Upload(filePath, filename, source *string, target string) (*Audio, error) {
-	return a.UploadWithOptions(filePath, filename, source, target, nil)
+	return a.UploadCtx(context.Background(), filePath, filename, source, target)
+}
+
+// UploadCtx is like Upload but honors ctx cancellation and deadlines.
+func (a *AudioTranslator) UploadCtx(ctx context.Context, filePath, filename, source *string, target string) (*Audio, error) {
+	return a.UploadWithOptionsCtx(ctx, filePath, filename, source, target, nil)
 }
 
 // UploadWithOptions uploads an audio file with advanced options
 func (a *AudioTranslator) UploadWithOptions(filePath, filename, source *string, target string, options *AudioUploadOptions) (*Audio, error) {
+	return a.UploadWithOptionsCtx(context.Background(), filePath, filename, source, target, options)
+}
+
+// UploadWithOptionsCtx is like UploadWithOptions but honors ctx cancellation and deadlines.
+func (a *AudioTranslator) UploadWithOptionsCtx(ctx context.Context, filePath, filename, source *string, target string, options *AudioUploadOptions) (*Audio, error) {
+	file, err := os.Open(*filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audio file: %w", err)
+	}
+	defer file.Close()
+
+	return a.UploadFromReader(ctx, NewUploadFile(file, *filename), source, target, options)
+}
+
+// UploadFromReader uploads audio content that does not need to be on disk and creates a translation job.
+// If the job was created but could not be recorded in the journal, the audio is returned along with a *JournalError
+func (a *AudioTranslator) UploadFromReader(ctx context.Context, file *UploadFile, source *string, target string, options *AudioUploadOptions) (*Audio, error) {
+	audio, _, err := a.upload(ctx, file, source, target, options)
+	return audio, err
+}
+
+// upload uploads file and creates its translation, recording both steps in
+// the journal set in options, if any
+func (a *AudioTranslator) upload(ctx context.Context, file *UploadFile, source *string, target string, options *AudioUploadOptions) (*Audio, *JournalEntry, error) {
+	file, cleanup, err := probeAudioUpload(file, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer cleanup()
+
+	s3Key, err := a.uploadFile(ctx, file)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	entry := newAudioEntry(s3Key, file.Filename, source, target, options)
+	audio, err := a.start(ctx, entry, audioJournalOf(options))
+	return audio, entry, err
+}
+
+// probeAudioUpload checks file with ProbeAudio and the upload limits before it
+// is uploaded, unless options.SkipProbe is set. The returned file must be uploaded in its place.
+func probeAudioUpload(file *UploadFile, options *AudioUploadOptions) (*UploadFile, func(), error) {
+	if options != nil && options.SkipProbe {
+		return file, func() {}, nil
+	}
+
+	limits := &AudioLimits{}
+	if options != nil && options.Limits != nil {
+		limits = options.Limits
+	}
+	_, upload, cleanup, err := probeUploadFile(file, limits)
+	return upload, cleanup, err
+}
+
+// uploadFile uploads file to S3 and returns its key
+func (a *AudioTranslator) uploadFile(ctx context.Context, file *UploadFile) (string, error) {
 	params := map[string]string{
-		"filename": *filename,
+		"filename": file.Filename,
 	}
 
 	var uploadResponse struct {
 		URL    string         `json:"url"`
 		Fields s3UploadFields `json:"fields"`
 	}
-	err := a.client.Get("/v2/audio/upload-url", params, nil, &uploadResponse)
+	err := a.client.Get(ctx, "/v2/audio/upload-url", params, nil, &uploadResponse)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get upload URL: %w", err)
+		return "", fmt.Errorf("failed to get upload URL: %w", err)
 	}
 
-	err = a.s3Client.Upload(uploadResponse.URL, uploadResponse.Fields, *filePath)
+	err = a.s3Client.UploadReader(ctx, uploadResponse.URL, uploadResponse.Fields, file)
 	if err != nil {
-		return nil, fmt.Errorf("failed to upload file to S3: %w", err)
+		return "", fmt.Errorf("failed to upload file to S3: %w", err)
 	}
 
-	body := map[string]interface{}{
-		"s3key":  uploadResponse.Fields["key"],
-		"target": target,
-	}
+	return uploadResponse.Fields["key"], nil
+}
 
-	if source != nil {
-		body["source"] = *source
+// newAudioEntry describes the translation of an uploaded file into target
+func newAudioEntry(s3Key, filename string, source *string, target string, options *AudioUploadOptions) *JournalEntry {
+	entry := &JournalEntry{
+		Kind:     JournalKindAudio,
+		S3Key:    s3Key,
+		Filename: filename,
+		Source:   source,
+		Target:   target,
+		Options:  map[string]interface{}{},
+		Status:   JournalStatusPending,
 	}
 
 	if options != nil {
 		if len(options.AdaptTo) > 0 {
-			body["adapt_to"] = options.AdaptTo
+			entry.Options["adapt_to"] = options.AdaptTo
 		}
 		if len(options.Glossaries) > 0 {
-			body["glossaries"] = options.Glossaries
+			entry.Options["glossaries"] = options.Glossaries
 		}
 		if options.Style != "" {
-			body["style"] = options.Style
+			entry.Options["style"] = options.Style
 		}
 		if options.VoiceGender != "" {
-			body["voice_gender"] = options.VoiceGender
+			entry.Options["voice_gender"] = options.VoiceGender
+		}
+		entry.NoTrace = options.NoTrace != nil && *options.NoTrace
+	}
+
+	return entry
+}
+
+func audioJournalOf(options *AudioUploadOptions) *Journal {
+	if options == nil {
+		return nil
+	}
+	return options.Journal
+}
+
+// start creates the translation described by entry, recording it in journal
+// before and after when journal is not nil. If the translation was created
+// but could not be recorded, the audio is returned along with a
+// *JournalError, so that it is not lost and paid for again
+func (a *AudioTranslator) start(ctx context.Context, entry *JournalEntry, journal *Journal) (*Audio, error) {
+	if journal != nil {
+		if err := journal.record(entry); err != nil {
+			return nil, err
 		}
 	}
 
-	var headers map[string]string
-	if options != nil && options.NoTrace != nil && *options.NoTrace {
-		headers = map[string]string{"X-No-Trace": "true"}
+	audio, err := a.create(ctx, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	entry.ID = audio.ID
+	if journal != nil {
+		if err := journal.record(entry); err != nil {
+			return audio, &JournalError{ID: audio.ID, Err: err}
+		}
 	}
+	return audio, nil
+}
 
+// create starts the translation of a file already uploaded to S3
+func (a *AudioTranslator) create(ctx context.Context, entry *JournalEntry) (*Audio, error) {
 	var audio Audio
-	err = a.client.Post("/v2/audio/translate", body, nil, headers, &audio)
+	err := a.client.Post(ctx, "/v2/audio/translate", entry.requestBody(), nil, entry.headers(), &audio)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create audio translation: %w", err)
 	}
@@ -83,8 +188,13 @@ func (a *AudioTranslator) UploadWithOptions(filePath, filename, source *string,
 
 // Status retrieves the current status of an audio translation job
 func (a *AudioTranslator) Status(id string) (*Audio, error) {
+	return a.StatusCtx(context.Background(), id)
+}
+
+// StatusCtx is like Status but honors ctx cancellation and deadlines.
+func (a *AudioTranslator) StatusCtx(ctx context.Context, id string) (*Audio, error) {
 	var audio Audio
-	err := a.client.Get(fmt.Sprintf("/v2/audio/%s", id), nil, nil, &audio)
+	err := a.client.Get(ctx, fmt.Sprintf("/v2/audio/%s", id), nil, nil, &audio)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get audio status: %w", err)
 	}
@@ -93,67 +203,397 @@ func (a *AudioTranslator) Status(id string) (*Audio, error) {
 
 // Download retrieves the translated audio file
 func (a *AudioTranslator) Download(id string) (io.ReadCloser, error) {
+	return a.DownloadCtx(context.Background(), id)
+}
+
+// DownloadCtx is like Download but honors ctx cancellation and deadlines.
+func (a *AudioTranslator) DownloadCtx(ctx context.Context, id string) (io.ReadCloser, error) {
+	downloadURL, err := a.downloadURL(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.s3Client.Download(ctx, downloadURL)
+}
+
+// DownloadToFile downloads the translated audio to path. The file appears
+// atomically once its length has been checked, and an interrupted transfer is
+// resumed with Range requests, even by a later call for the same path. When
+// path is a directory, the file is saved under the name suggested by the
+// server. onProgress may be nil.
+func (a *AudioTranslator) DownloadToFile(id, path string, onProgress DownloadProgressFunc) (*DownloadedFile, error) {
+	return a.DownloadToFileCtx(context.Background(), id, path, onProgress)
+}
+
+// DownloadToFileCtx is like DownloadToFile but honors ctx cancellation and deadlines.
+func (a *AudioTranslator) DownloadToFileCtx(ctx context.Context, id, path string, onProgress DownloadProgressFunc) (*DownloadedFile, error) {
+	downloadURL, err := a.downloadURL(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.s3Client.downloadToFile(ctx, downloadURL, path, "", onProgress)
+}
+
+func (a *AudioTranslator) downloadURL(ctx context.Context, id string) (string, error) {
 	var downloadResponse struct {
 		URL string `json:"url"`
 	}
-	err := a.client.Get(fmt.Sprintf("/v2/audio/%s/download-url", id), nil, nil, &downloadResponse)
+	err := a.client.Get(ctx, fmt.Sprintf("/v2/audio/%s/download-url", id), nil, nil, &downloadResponse)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get download URL: %w", err)
+		return "", fmt.Errorf("failed to get download URL: %w", err)
 	}
 
-	return a.s3Client.Download(downloadResponse.URL)
+	return downloadResponse.URL, nil
 }
 
 // Translate performs a complete translation workflow: upload, wait, and download
 func (a *AudioTranslator) Translate(filePath, filename, source *string, target string) (io.ReadCloser, error) {
-	return a.TranslateWithOptions(filePath, filename, source, target, nil)
+	return a.TranslateCtx(context.Background(), filePath, filename, source, target)
+}
+
+// TranslateCtx is like Translate but honors ctx cancellation and deadlines.
+func (a *AudioTranslator) TranslateCtx(ctx context.Context, filePath, filename, source *string, target string) (io.ReadCloser, error) {
+	return a.TranslateWithOptionsCtx(ctx, filePath, filename, source, target, nil)
 }
 
 // TranslateWithOptions performs a complete translation workflow with options
 func (a *AudioTranslator) TranslateWithOptions(filePath, filename, source *string, target string, options *AudioUploadOptions) (io.ReadCloser, error) {
-	audio, err := a.UploadWithOptions(filePath, filename, source, target, options)
+	return a.TranslateWithOptionsCtx(context.Background(), filePath, filename, source, target, options)
+}
+
+// TranslateWithOptionsCtx is like TranslateWithOptions but honors ctx cancellation and deadlines.
+func (a *AudioTranslator) TranslateWithOptionsCtx(ctx context.Context, filePath, filename, source *string, target string, options *AudioUploadOptions) (io.ReadCloser, error) {
+	file, err := os.Open(*filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audio file: %w", err)
+	}
+	defer file.Close()
+
+	return a.TranslateFromReader(ctx, NewUploadFile(file, *filename), source, target, options)
+}
+
+// TranslateFromReader performs a complete translation workflow for audio content that does not need to be on disk.
+// If the job was created but could not be recorded in the journal, it returns a *JournalError carrying the audio ID
+// without waiting for it
+func (a *AudioTranslator) TranslateFromReader(ctx context.Context, file *UploadFile, source *string, target string, options *AudioUploadOptions) (io.ReadCloser, error) {
+	audio, entry, err := a.upload(ctx, file, source, target, options)
+	if err != nil {
+		return nil, err
+	}
+
+	journal := audioJournalOf(options)
+	if _, err := a.await(ctx, audio, entry, journal, audioWaitOptionsOf(options)); err != nil {
+		return nil, err
+	}
+	return a.open(ctx, entry, journal)
+}
+
+// AudioResult is the outcome of translating audio into one of the targets of TranslateMulti.
+// If the job was created but could not be recorded in the journal, Audio is set and Err is a *JournalError
+type AudioResult struct {
+	Audio *Audio
+	Err   error
+	open  func(ctx context.Context) (io.ReadCloser, error)
+}
+
+// Download streams the translated audio, or returns Err if the translation failed
+func (r *AudioResult) Download(ctx context.Context) (io.ReadCloser, error) {
+	if r.Err != nil {
+		return nil, r.Err
+	}
+	return r.open(ctx)
+}
+
+// TranslateMulti uploads an audio file once and translates it into every target
+// language, waiting for the translations concurrently. The result for each target
+// carries its own error; the returned error is only set when the upload itself fails.
+func (a *AudioTranslator) TranslateMulti(filePath, filename, source *string, targets []string, options *AudioUploadOptions) (map[string]*AudioResult, error) {
+	return a.TranslateMultiCtx(context.Background(), filePath, filename, source, targets, options)
+}
+
+// TranslateMultiCtx is like TranslateMulti but honors ctx cancellation and deadlines.
+func (a *AudioTranslator) TranslateMultiCtx(ctx context.Context, filePath, filename, source *string, targets []string, options *AudioUploadOptions) (map[string]*AudioResult, error) {
+	file, err := os.Open(*filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audio file: %w", err)
+	}
+	defer file.Close()
+
+	return a.TranslateMultiFromReader(ctx, NewUploadFile(file, *filename), source, targets, options)
+}
+
+// TranslateMultiFromReader is like TranslateMulti for audio content that does not need to be on disk
+func (a *AudioTranslator) TranslateMultiFromReader(ctx context.Context, file *UploadFile, source *string, targets []string, options *AudioUploadOptions) (map[string]*AudioResult, error) {
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("%w: at least one target language is required", ErrValidation)
+	}
+
+	journal := audioJournalOf(options)
+
+	file, cleanup, err := probeAudioUpload(file, options)
 	if err != nil {
 		return nil, err
 	}
+	defer cleanup()
 
-	audio, err = a.waitForCompletion(audio)
+	s3Key, err := a.uploadFile(ctx, file)
 	if err != nil {
 		return nil, err
 	}
 
-	if audio.Status == AudioStatusError {
-		errorMsg := "translation failed"
-		if audio.ErrorReason != nil {
-			errorMsg = *audio.ErrorReason
+	results := make(map[string]*AudioResult, len(targets))
+	var wg sync.WaitGroup
+	for _, target := range targets {
+		if _, ok := results[target]; ok {
+			continue
 		}
-		return nil, fmt.Errorf("audio translation failed: %s", errorMsg)
+		result := &AudioResult{}
+		results[target] = result
+
+		wg.Add(1)
+		go func(target string, result *AudioResult) {
+			defer wg.Done()
+
+			entry := newAudioEntry(s3Key, file.Filename, source, target, options)
+			audio, err := a.start(ctx, entry, journal)
+			if err != nil {
+				result.Audio, result.Err = audio, err
+				return
+			}
+
+			result.Audio, result.Err = a.await(ctx, audio, entry, journal, audioWaitOptionsOf(options))
+			result.open = func(ctx context.Context) (io.ReadCloser, error) {
+				return a.open(ctx, entry, journal)
+			}
+		}(target, result)
+	}
+	wg.Wait()
+
+	return results, nil
+}
+
+func audioWaitOptionsOf(options *AudioUploadOptions) *AudioWaitOptions {
+	if options == nil {
+		return nil
+	}
+	return options.Wait
+}
+
+// await waits for the translation of audio and records a failure in journal
+func (a *AudioTranslator) await(ctx context.Context, audio *Audio, entry *JournalEntry, journal *Journal, options *AudioWaitOptions) (*Audio, error) {
+	audio, err := a.WaitCtx(ctx, audio, options)
+	if err != nil && journal != nil && audio.Status == AudioStatusError {
+		journal.finish(entry, err)
+	}
+	return audio, err
+}
+
+// open downloads the translation described by entry. With a journal, the entry
+// is marked completed once the output has been read to the end and closed
+func (a *AudioTranslator) open(ctx context.Context, entry *JournalEntry, journal *Journal) (io.ReadCloser, error) {
+	reader, err := a.DownloadCtx(ctx, entry.ID)
+	if err != nil {
+		return nil, err
+	}
+	if journal != nil {
+		return &journaledReader{ReadCloser: reader, journal: journal, entry: entry}, nil
 	}
+	return reader, nil
+}
 
-	return a.Download(audio.ID)
+// Resume finishes the audio translations left pending in journal by an earlier
+// process, without uploading the files again. For each of them it waits for the
+// translation and passes the translated audio to handle.
+func (a *AudioTranslator) Resume(journal *Journal, handle ResumeHandler) error {
+	return a.ResumeWithOptionsCtx(context.Background(), journal, nil, handle)
 }
 
-// waitForCompletion polls the API until the translation is complete
-func (a *AudioTranslator) waitForCompletion(audio *Audio) (*Audio, error) {
-	pollingInterval := 2 * time.Second
-	maxWaitTime := 15 * time.Minute
+// ResumeCtx is like Resume but honors ctx cancellation and deadlines.
+func (a *AudioTranslator) ResumeCtx(ctx context.Context, journal *Journal, handle ResumeHandler) error {
+	return a.ResumeWithOptionsCtx(ctx, journal, nil, handle)
+}
 
-	start := time.Now()
-	current := audio
+// ResumeWithOptions is like Resume but waits for each translation as
+// configured by options, which may be nil.
+func (a *AudioTranslator) ResumeWithOptions(journal *Journal, options *AudioWaitOptions, handle ResumeHandler) error {
+	return a.ResumeWithOptionsCtx(context.Background(), journal, options, handle)
+}
 
-	for current.Status != AudioStatusTranslated && current.Status != AudioStatusError {
-		if time.Since(start) > maxWaitTime {
-			return current, fmt.Errorf("timeout waiting for translation to complete")
+// ResumeWithOptionsCtx is like ResumeWithOptions but honors ctx cancellation and deadlines.
+func (a *AudioTranslator) ResumeWithOptionsCtx(ctx context.Context, journal *Journal, options *AudioWaitOptions, handle ResumeHandler) error {
+	return resumePending(ctx, journal, JournalKindAudio, handle, func(ctx context.Context, entry *JournalEntry) (*resumeResult, error) {
+		var audio *Audio
+		var err error
+		if entry.ID == "" {
+			audio, err = a.create(ctx, entry)
+			if err != nil {
+				return nil, err
+			}
+			entry.ID = audio.ID
+			if err := journal.record(entry); err != nil {
+				return nil, &JournalError{ID: audio.ID, Err: err}
+			}
+		} else {
+			audio, err = a.StatusCtx(ctx, entry.ID)
+			if errors.Is(err, ErrNotFound) {
+				return &resumeResult{failure: err}, nil
+			}
+			if err != nil {
+				return nil, err
+			}
 		}
 
-		time.Sleep(pollingInterval)
+		audio, err = a.WaitCtx(ctx, audio, options)
+		if err != nil {
+			if audio.Status == AudioStatusError {
+				return &resumeResult{failure: err}, nil
+			}
+			return nil, err
+		}
 
-		updated, err := a.Status(current.ID)
+		content, err := a.DownloadCtx(ctx, audio.ID)
 		if err != nil {
-			return current, err
+			return nil, err
 		}
+		return &resumeResult{content: content}, nil
+	})
+}
+
+// Wait polls the audio translation until it is translated or fails, and
+// returns its last observed state. If options.MaxWaitTime elapses first, the
+// error is a *WaitTimeoutError carrying the audio ID, so that the wait can be
+// resumed later with Status and Wait.
+func (a *AudioTranslator) Wait(audio *Audio, options *AudioWaitOptions) (*Audio, error) {
+	return a.WaitCtx(context.Background(), audio, options)
+}
 
-		current = updated
+// WaitCtx is like Wait but honors ctx cancellation and deadlines.
+func (a *AudioTranslator) WaitCtx(ctx context.Context, audio *Audio, options *AudioWaitOptions) (*Audio, error) {
+	if options == nil {
+		options = &AudioWaitOptions{}
 	}
 
-	return current, nil
+	job := a.Job(audio)
+	waitOptions := &WaitOptions{
+		PollInterval:    options.PollInterval,
+		BackoffFactor:   options.BackoffFactor,
+		MaxPollInterval: options.MaxPollInterval,
+		MaxWaitTime:     options.MaxWaitTime,
+	}
+	if waitOptions.MaxWaitTime == 0 {
+		waitOptions.MaxWaitTime = defaultAudioMaxWaitTime
+	} else if waitOptions.MaxWaitTime < 0 {
+		waitOptions.MaxWaitTime = 0
+	}
+	if options.OnProgress != nil {
+		estimator := newAudioETA(audio)
+		waitOptions.OnProgress = func(Job) { options.OnProgress(estimator.progress(job.Audio())) }
+	}
+
+	if err := job.Wait(ctx, waitOptions); err != nil && !job.Done() {
+		return job.Audio(), fmt.Errorf("failed to wait for audio translation: %w", err)
+	}
+	return job.Audio(), job.Err()
+}
+
+// defaultAudioMaxWaitTime bounds AudioTranslator.Wait when no maximum is set.
+const defaultAudioMaxWaitTime = 15 * time.Minute
+
+// audioETA estimates the time left from the rate at which translated seconds
+// grew since the wait started.
+type audioETA struct {
+	start      time.Time
+	translated float64
+}
+
+func newAudioETA(audio *Audio) *audioETA {
+	e := &audioETA{start: time.Now()}
+	if audio.TranslatedSeconds != nil {
+		e.translated = *audio.TranslatedSeconds
+	}
+	return e
+}
+
+func (e *audioETA) progress(audio *Audio) AudioProgress {
+	p := AudioProgress{Audio: audio, Elapsed: time.Since(e.start), ETA: -1}
+	if audio.TranslatedSeconds != nil {
+		p.TranslatedSeconds = *audio.TranslatedSeconds
+	}
+	if audio.TotalSeconds != nil {
+		p.TotalSeconds = *audio.TotalSeconds
+	}
+
+	switch {
+	case audio.Status == AudioStatusTranslated:
+		p.ETA = 0
+	case p.TotalSeconds > 0 && p.TranslatedSeconds > e.translated && p.Elapsed > 0:
+		rate := (p.TranslatedSeconds - e.translated) / p.Elapsed.Seconds()
+		remaining := p.TotalSeconds - p.TranslatedSeconds
+		if remaining < 0 {
+			remaining = 0
+		}
+		p.ETA = time.Duration(remaining / rate * float64(time.Second))
+	}
+	return p
+}
+
+// Job returns a Job tracking the translation of audio.
+func (a *AudioTranslator) Job(audio *Audio) *AudioJob {
+	status, progress, done, err := audioState(audio)
+	return &AudioJob{
+		jobState: newJobState(audio.ID, status, progress, done, err),
+		service:  a,
+		audio:    audio,
+	}
+}
+
+// AudioJob tracks an audio translation.
+type AudioJob struct {
+	*jobState
+	service *AudioTranslator
+	audio   *Audio
+}
+
+// Audio returns the last observed state of the audio translation.
+func (j *AudioJob) Audio() *Audio {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.audio
+}
+
+// Refresh fetches the current status of the audio translation.
+func (j *AudioJob) Refresh(ctx context.Context) error {
+	audio, err := j.service.StatusCtx(ctx, j.ID())
+	if err != nil {
+		return err
+	}
+
+	status, progress, done, failure := audioState(audio)
+	j.update(status, progress, done, failure, func() { j.audio = audio })
+	return nil
+}
+
+// Wait polls the audio translation until it completes.
+func (j *AudioJob) Wait(ctx context.Context, options *WaitOptions) error {
+	return waitJob(ctx, j, j.jobState, options)
+}
+
+func audioState(audio *Audio) (status string, progress float64, done bool, err error) {
+	progress = -1
+	if audio.TranslatedSeconds != nil && audio.TotalSeconds != nil && *audio.TotalSeconds > 0 {
+		progress = *audio.TranslatedSeconds / *audio.TotalSeconds
+	}
+
+	switch audio.Status {
+	case AudioStatusTranslated:
+		return string(audio.Status), 1, true, nil
+	case AudioStatusError:
+		errorMsg := "translation failed"
+		if audio.ErrorReason != nil {
+			errorMsg = *audio.ErrorReason
+		}
+		return string(audio.Status), progress, true, fmt.Errorf("audio translation failed: %s", errorMsg)
+	}
+	return string(audio.Status), progress, false, nil
 }
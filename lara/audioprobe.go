@@ -0,0 +1,741 @@
+package lara
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// Audio containers recognized by ProbeAudio.
+const (
+	AudioFormatWav  = "wav"
+	AudioFormatMp3  = "mp3"
+	AudioFormatFlac = "flac"
+	AudioFormatOgg  = "ogg"
+	AudioFormatM4a  = "m4a"
+)
+
+// AudioInfo describes an audio file, as read from its headers by ProbeAudio.
+type AudioInfo struct {
+	// Format is the container, one of the AudioFormat constants.
+	Format string
+	// Codec is the audio encoding, e.g. "pcm", "mp3", "flac", "vorbis",
+	// "opus", "aac" or "alac".
+	Codec string
+	// Duration is zero when the headers do not record it.
+	Duration   time.Duration
+	SampleRate int
+	Channels   int
+	// BitRate is the average bit rate in bits per second, or 0 when unknown.
+	BitRate int
+	// Size is the file size in bytes.
+	Size int64
+}
+
+// AudioLimits bounds the audio files accepted for upload. Zero fields are not checked.
+type AudioLimits struct {
+	MaxDuration time.Duration
+	MaxSize     int64
+	// Codecs, when not empty, lists the accepted AudioInfo.Codec values.
+	// Files whose codec the probe cannot tell are always accepted.
+	Codecs []string
+}
+
+// ProbeAudio reads the headers of a WAV, MP3, FLAC, OGG (Vorbis, Opus, FLAC)
+// or M4A file of the given size. It only reads the few blocks it needs, so it
+// is cheap even for long recordings. An unrecognized or malformed file yields
+// an error matching ErrValidation.
+func ProbeAudio(r io.ReaderAt, size int64) (*AudioInfo, error) {
+	info, err := probeAudio(r, size)
+	if err != nil {
+		return nil, err
+	}
+	if info == nil {
+		return nil, fmt.Errorf("%w: unrecognized audio format", ErrValidation)
+	}
+	return info, nil
+}
+
+// ProbeAudioFile is like ProbeAudio for the file at path.
+func ProbeAudioFile(path string) (*AudioInfo, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audio file: %w", err)
+	}
+	defer file.Close()
+
+	stat, err := file.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audio file: %w", err)
+	}
+	return ProbeAudio(file, stat.Size())
+}
+
+// probeAudio returns nil info, and no error, when the container is not recognized.
+func probeAudio(r io.ReaderAt, size int64) (*AudioInfo, error) {
+	head := make([]byte, 12)
+	n, err := r.ReadAt(head, 0)
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("failed to read audio file: %w", err)
+	}
+	head = head[:n]
+
+	var info *AudioInfo
+	switch {
+	case len(head) >= 12 && string(head[0:4]) == "RIFF" && string(head[8:12]) == "WAVE":
+		info, err = probeWav(r, size)
+	case len(head) >= 4 && string(head[0:4]) == "fLaC":
+		info, err = probeFlac(r, 0)
+	case len(head) >= 4 && string(head[0:4]) == "OggS":
+		info, err = probeOgg(r, size)
+	case len(head) >= 8 && string(head[4:8]) == "ftyp":
+		info, err = probeMp4(r, size)
+	default:
+		// MP3 files, and some FLAC files, start with an ID3v2 tag.
+		offset := id3v2Size(head)
+		if offset > 0 {
+			if magic, _ := readAt(r, offset, 4); string(magic) == "fLaC" {
+				info, err = probeFlac(r, offset)
+				break
+			}
+		}
+		info, err = probeMp3(r, offset, size)
+	}
+	if info != nil {
+		info.Size = size
+		if info.BitRate == 0 && info.Duration > 0 {
+			info.BitRate = int(float64(size*8) / info.Duration.Seconds())
+		}
+	}
+	return info, err
+}
+
+// validateAudio checks the duration and codec of a probed file against limits.
+func validateAudio(filename string, info *AudioInfo, limits *AudioLimits) error {
+	if limits.MaxDuration > 0 && info.Duration > limits.MaxDuration {
+		return fmt.Errorf("%w: %q lasts %s, the limit is %s", ErrValidation, filename, info.Duration.Round(time.Second), limits.MaxDuration)
+	}
+	if len(limits.Codecs) > 0 && info.Codec != "" && info.Codec != "unknown" {
+		for _, codec := range limits.Codecs {
+			if codec == info.Codec {
+				return nil
+			}
+		}
+		return fmt.Errorf("%w: %q uses the %s codec, accepted codecs are %s", ErrValidation, filename, info.Codec, strings.Join(limits.Codecs, ", "))
+	}
+	return nil
+}
+
+// probeUploadFile probes and validates an audio upload. Readers that cannot
+// seek are spooled to a temporary file first; the returned UploadFile must
+// then be uploaded in place of file, and cleanup called when done.
+func probeUploadFile(file *UploadFile, limits *AudioLimits) (info *AudioInfo, upload *UploadFile, cleanup func(), err error) {
+	if file == nil || file.Reader == nil {
+		return nil, nil, nil, fmt.Errorf("upload file has no content")
+	}
+	upload = file
+	cleanup = func() {}
+
+	var r io.ReaderAt
+	var size int64
+	if seeker, ok := file.Reader.(io.ReadSeeker); ok {
+		if offset, err := seeker.Seek(0, io.SeekCurrent); err == nil {
+			end, err := seeker.Seek(0, io.SeekEnd)
+			if err != nil {
+				return nil, nil, nil, fmt.Errorf("failed to read audio file: %w", err)
+			}
+			defer seeker.Seek(offset, io.SeekStart)
+			r = &seekerReaderAt{r: seeker, base: offset}
+			size = end - offset
+		}
+	}
+	if r == nil {
+		tmp, err := os.CreateTemp("", "lara-upload-*")
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to create temporary file: %w", err)
+		}
+		cleanup = func() {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+		if size, err = io.Copy(tmp, file.Reader); err != nil {
+			cleanup()
+			return nil, nil, nil, fmt.Errorf("failed to read file data: %w", err)
+		}
+		if file.Size > 0 && size != file.Size {
+			cleanup()
+			return nil, nil, nil, fmt.Errorf("upload file %s: expected %d bytes, read %d", file.Filename, file.Size, size)
+		}
+		if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+			cleanup()
+			return nil, nil, nil, fmt.Errorf("failed to rewind file: %w", err)
+		}
+		spooled := *file
+		spooled.Reader = tmp
+		upload = &spooled
+		r = tmp
+	}
+
+	fail := func(err error) (*AudioInfo, *UploadFile, func(), error) {
+		cleanup()
+		return nil, nil, nil, err
+	}
+
+	if size == 0 {
+		return fail(fmt.Errorf("%w: audio file %q is empty", ErrValidation, file.Filename))
+	}
+	if limits.MaxSize > 0 && size > limits.MaxSize {
+		return fail(fmt.Errorf("%w: %q is %d bytes, the limit is %d", ErrValidation, file.Filename, size, limits.MaxSize))
+	}
+	info, err = probeAudio(r, size)
+	if err != nil {
+		return fail(fmt.Errorf("%w (%s)", err, file.Filename))
+	}
+	if info == nil {
+		// Not a container the probe knows: leave it for the API to judge.
+		return nil, upload, cleanup, nil
+	}
+	if err := validateAudio(file.Filename, info, limits); err != nil {
+		return fail(err)
+	}
+	return info, upload, cleanup, nil
+}
+
+// seekerReaderAt reads from an io.ReadSeeker at offsets relative to base.
+type seekerReaderAt struct {
+	r    io.ReadSeeker
+	base int64
+}
+
+func (s *seekerReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	if _, err := s.r.Seek(s.base+off, io.SeekStart); err != nil {
+		return 0, err
+	}
+	n, err := io.ReadFull(s.r, p)
+	if err == io.ErrUnexpectedEOF {
+		err = io.EOF
+	}
+	return n, err
+}
+
+// readAt reads exactly n bytes at off.
+func readAt(r io.ReaderAt, off int64, n int) ([]byte, error) {
+	buf := make([]byte, n)
+	read, err := r.ReadAt(buf, off)
+	if read == n {
+		return buf, nil
+	}
+	if err == nil || err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return nil, err
+}
+
+func malformedAudio(format string) error {
+	return fmt.Errorf("%w: malformed %s file", ErrValidation, format)
+}
+
+func seconds(value float64) time.Duration {
+	return time.Duration(value * float64(time.Second))
+}
+
+// id3v2Size returns the length of the ID3v2 tag at the start of head, or 0.
+func id3v2Size(head []byte) int64 {
+	if len(head) < 10 || string(head[0:3]) != "ID3" {
+		return 0
+	}
+	size := int64(head[6]&0x7f)<<21 | int64(head[7]&0x7f)<<14 | int64(head[8]&0x7f)<<7 | int64(head[9]&0x7f)
+	size += 10
+	if head[5]&0x10 != 0 {
+		size += 10 // footer
+	}
+	return size
+}
+
+var wavCodecs = map[uint16]string{
+	0x0001: "pcm",
+	0x0003: "float",
+	0x0006: "alaw",
+	0x0007: "mulaw",
+	0x0050: "mp2",
+	0x0055: "mp3",
+}
+
+func probeWav(r io.ReaderAt, size int64) (*AudioInfo, error) {
+	info := &AudioInfo{Format: AudioFormatWav}
+	var byteRate uint32
+	var haveFmt bool
+
+	offset := int64(12)
+	for offset+8 <= size {
+		header, err := readAt(r, offset, 8)
+		if err != nil {
+			return nil, malformedAudio(AudioFormatWav)
+		}
+		id := string(header[0:4])
+		length := int64(binary.LittleEndian.Uint32(header[4:8]))
+		offset += 8
+
+		switch id {
+		case "fmt ":
+			if length < 16 {
+				return nil, malformedAudio(AudioFormatWav)
+			}
+			n := length
+			if n > 40 {
+				n = 40
+			}
+			chunk, err := readAt(r, offset, int(n))
+			if err != nil {
+				return nil, malformedAudio(AudioFormatWav)
+			}
+			tag := binary.LittleEndian.Uint16(chunk[0:2])
+			if tag == 0xfffe && len(chunk) >= 26 {
+				// WAVE_FORMAT_EXTENSIBLE: the codec is the first two bytes of the subformat GUID.
+				tag = binary.LittleEndian.Uint16(chunk[24:26])
+			}
+			info.Codec = wavCodecs[tag]
+			if info.Codec == "" {
+				info.Codec = fmt.Sprintf("wav-0x%04x", tag)
+			}
+			info.Channels = int(binary.LittleEndian.Uint16(chunk[2:4]))
+			info.SampleRate = int(binary.LittleEndian.Uint32(chunk[4:8]))
+			byteRate = binary.LittleEndian.Uint32(chunk[8:12])
+			info.BitRate = int(byteRate) * 8
+			haveFmt = true
+		case "data":
+			if !haveFmt {
+				return nil, malformedAudio(AudioFormatWav)
+			}
+			// Streaming writers leave the size unset.
+			if length == 0xffffffff || offset+length > size {
+				length = size - offset
+			}
+			if length == 0 {
+				return nil, fmt.Errorf("%w: WAV file contains no audio", ErrValidation)
+			}
+			if byteRate > 0 {
+				info.Duration = seconds(float64(length) / float64(byteRate))
+			}
+			return info, nil
+		}
+		offset += length + length%2
+	}
+	return nil, malformedAudio(AudioFormatWav)
+}
+
+// probeFlac reads the STREAMINFO block of a FLAC stream starting at offset.
+func probeFlac(r io.ReaderAt, offset int64) (*AudioInfo, error) {
+	block, err := readAt(r, offset+4, 4+34)
+	if err != nil || block[0]&0x7f != 0 {
+		return nil, malformedAudio(AudioFormatFlac)
+	}
+
+	info := parseFlacStreamInfo(block[4:])
+	info.Format = AudioFormatFlac
+	return info, nil
+}
+
+// parseFlacStreamInfo parses the 34-byte body of a STREAMINFO block.
+func parseFlacStreamInfo(data []byte) *AudioInfo {
+	packed := binary.BigEndian.Uint64(data[10:18])
+	sampleRate := int(packed >> 44)
+	channels := int(packed>>41&0x7) + 1
+	samples := packed & 0xfffffffff
+
+	info := &AudioInfo{Codec: "flac", SampleRate: sampleRate, Channels: channels}
+	if sampleRate > 0 {
+		info.Duration = seconds(float64(samples) / float64(sampleRate))
+	}
+	return info
+}
+
+func probeOgg(r io.ReaderAt, size int64) (*AudioInfo, error) {
+	header, err := readAt(r, 0, 27)
+	if err != nil {
+		return nil, malformedAudio(AudioFormatOgg)
+	}
+	serial := binary.LittleEndian.Uint32(header[14:18])
+	segments, err := readAt(r, 27, int(header[26]))
+	if err != nil {
+		return nil, malformedAudio(AudioFormatOgg)
+	}
+	packetSize := 0
+	for _, s := range segments {
+		packetSize += int(s)
+		if s < 255 {
+			break
+		}
+	}
+	packet, err := readAt(r, 27+int64(len(segments)), packetSize)
+	if err != nil {
+		return nil, malformedAudio(AudioFormatOgg)
+	}
+
+	info := &AudioInfo{Format: AudioFormatOgg}
+	// granuleRate converts the granule position to seconds, after preSkip samples.
+	var granuleRate, preSkip float64
+	switch {
+	case len(packet) >= 28 && packet[0] == 1 && string(packet[1:7]) == "vorbis":
+		info.Codec = "vorbis"
+		info.Channels = int(packet[11])
+		info.SampleRate = int(binary.LittleEndian.Uint32(packet[12:16]))
+		if nominal := int32(binary.LittleEndian.Uint32(packet[20:24])); nominal > 0 {
+			info.BitRate = int(nominal)
+		}
+		granuleRate = float64(info.SampleRate)
+	case len(packet) >= 19 && string(packet[0:8]) == "OpusHead":
+		info.Codec = "opus"
+		info.Channels = int(packet[9])
+		info.SampleRate = int(binary.LittleEndian.Uint32(packet[12:16]))
+		// Opus granule positions always count 48 kHz samples.
+		granuleRate = 48000
+		preSkip = float64(binary.LittleEndian.Uint16(packet[10:12]))
+	case len(packet) >= 51 && string(packet[0:5]) == "\x7fFLAC" && string(packet[9:13]) == "fLaC":
+		flac := parseFlacStreamInfo(packet[17:51])
+		info.Codec = flac.Codec
+		info.Channels = flac.Channels
+		info.SampleRate = flac.SampleRate
+		granuleRate = float64(flac.SampleRate)
+	case len(packet) >= 8 && string(packet[0:8]) == "Speex   ":
+		info.Codec = "speex"
+		return info, nil
+	default:
+		info.Codec = "unknown"
+		return info, nil
+	}
+
+	if granule, ok := lastOggGranule(r, size, serial); ok && granuleRate > 0 {
+		if samples := float64(granule) - preSkip; samples > 0 {
+			info.Duration = seconds(samples / granuleRate)
+		}
+	}
+	return info, nil
+}
+
+// lastOggGranule finds the granule position of the last page of the stream,
+// which holds the total number of samples.
+func lastOggGranule(r io.ReaderAt, size int64, serial uint32) (uint64, bool) {
+	const tail = 64 * 1024
+	start := size - tail
+	if start < 0 {
+		start = 0
+	}
+	buf, err := readAt(r, start, int(size-start))
+	if err != nil {
+		return 0, false
+	}
+
+	for i := bytes.LastIndex(buf, []byte("OggS")); i >= 0; i = bytes.LastIndex(buf[:i], []byte("OggS")) {
+		if i+27 > len(buf) {
+			continue
+		}
+		page := buf[i:]
+		granule := binary.LittleEndian.Uint64(page[6:14])
+		if binary.LittleEndian.Uint32(page[14:18]) == serial && granule != 0xffffffffffffffff {
+			return granule, true
+		}
+	}
+	return 0, false
+}
+
+var (
+	mp3SampleRates = [4][3]int{
+		{11025, 12000, 8000},  // MPEG 2.5
+		{},                    // reserved
+		{22050, 24000, 16000}, // MPEG 2
+		{44100, 48000, 32000}, // MPEG 1
+	}
+	// mp3BitRates is indexed by [MPEG 1][layer - 1][index], in kbit/s.
+	mp3BitRates = [2][3][16]int{
+		{ // MPEG 2 and 2.5
+			{0, 32, 48, 56, 64, 80, 96, 112, 128, 144, 160, 176, 192, 224, 256},
+			{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160},
+			{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160},
+		},
+		{ // MPEG 1
+			{0, 32, 64, 96, 128, 160, 192, 224, 256, 288, 320, 352, 384, 416, 448},
+			{0, 32, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320, 384},
+			{0, 32, 40, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320},
+		},
+	}
+)
+
+// mp3Frame is a decoded MPEG audio frame header.
+type mp3Frame struct {
+	mpeg1      bool
+	layer      int
+	bitRate    int
+	sampleRate int
+	channels   int
+	length     int
+	samples    int
+}
+
+func parseMp3Frame(h []byte) (mp3Frame, bool) {
+	if len(h) < 4 || h[0] != 0xff || h[1]&0xe0 != 0xe0 {
+		return mp3Frame{}, false
+	}
+	version := int(h[1] >> 3 & 3)
+	layer := 4 - int(h[1]>>1&3)
+	bitRateIndex := int(h[2] >> 4)
+	sampleRateIndex := int(h[2] >> 2 & 3)
+	if version == 1 || layer == 4 || bitRateIndex == 0 || bitRateIndex == 15 || sampleRateIndex == 3 {
+		return mp3Frame{}, false
+	}
+
+	f := mp3Frame{mpeg1: version == 3, layer: layer, channels: 2}
+	mpeg := 0
+	if f.mpeg1 {
+		mpeg = 1
+	}
+	f.bitRate = mp3BitRates[mpeg][layer-1][bitRateIndex] * 1000
+	f.sampleRate = mp3SampleRates[version][sampleRateIndex]
+	if h[3]>>6 == 3 {
+		f.channels = 1
+	}
+	padding := int(h[2] >> 1 & 1)
+
+	switch {
+	case layer == 1:
+		f.samples = 384
+		f.length = (12*f.bitRate/f.sampleRate + padding) * 4
+	case layer == 3 && !f.mpeg1:
+		f.samples = 576
+		f.length = 72*f.bitRate/f.sampleRate + padding
+	default:
+		f.samples = 1152
+		f.length = 144*f.bitRate/f.sampleRate + padding
+	}
+	return f, true
+}
+
+// probeMp3 looks for two consecutive MPEG audio frames in the first 64 KiB
+// after offset, and takes the duration from a Xing or VBRI header when the
+// file has one, or from the bit rate otherwise.
+func probeMp3(r io.ReaderAt, offset, size int64) (*AudioInfo, error) {
+	const window = 64 * 1024
+	n := size - offset
+	if n > window+4 {
+		n = window + 4
+	}
+	if n < 4 {
+		return nil, nil
+	}
+	buf, err := readAt(r, offset, int(n))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read audio file: %w", err)
+	}
+
+	for i := 0; i+4 <= len(buf); i++ {
+		frame, ok := parseMp3Frame(buf[i:])
+		if !ok {
+			continue
+		}
+		start := offset + int64(i)
+		if next := start + int64(frame.length); next+4 <= size {
+			header, err := readAt(r, next, 4)
+			if err != nil {
+				continue
+			}
+			if _, ok := parseMp3Frame(header); !ok {
+				continue
+			}
+		}
+		return mp3Info(r, start, size, frame), nil
+	}
+	return nil, nil
+}
+
+func mp3Info(r io.ReaderAt, start, size int64, frame mp3Frame) *AudioInfo {
+	info := &AudioInfo{
+		Format:     AudioFormatMp3,
+		Codec:      [4]string{"", "mp1", "mp2", "mp3"}[frame.layer],
+		SampleRate: frame.sampleRate,
+		Channels:   frame.channels,
+	}
+
+	audioBytes := size - start
+	if tag, err := readAt(r, size-128, 3); err == nil && string(tag) == "TAG" {
+		audioBytes -= 128
+	}
+
+	// The first frame of a VBR file holds the frame count instead of audio.
+	var frames uint32
+	sideInfo := 32
+	switch {
+	case frame.mpeg1 && frame.channels == 1:
+		sideInfo = 17
+	case !frame.mpeg1 && frame.channels == 2:
+		sideInfo = 17
+	case !frame.mpeg1:
+		sideInfo = 9
+	}
+	if xing, err := readAt(r, start+4+int64(sideInfo), 12); err == nil && (string(xing[0:4]) == "Xing" || string(xing[0:4]) == "Info") {
+		if binary.BigEndian.Uint32(xing[4:8])&1 != 0 {
+			frames = binary.BigEndian.Uint32(xing[8:12])
+		}
+	} else if vbri, err := readAt(r, start+36, 18); err == nil && string(vbri[0:4]) == "VBRI" {
+		frames = binary.BigEndian.Uint32(vbri[14:18])
+	}
+
+	if frames > 0 {
+		info.Duration = seconds(float64(frames) * float64(frame.samples) / float64(frame.sampleRate))
+	} else if frame.bitRate > 0 {
+		info.BitRate = frame.bitRate
+		info.Duration = seconds(float64(audioBytes*8) / float64(frame.bitRate))
+	}
+	return info
+}
+
+// mp4Box is a box of an ISO base media (MP4) file.
+type mp4Box struct {
+	typ        string
+	start, end int64 // payload bounds
+}
+
+// mp4Children lists the boxes between start and end.
+func mp4Children(r io.ReaderAt, start, end int64) ([]mp4Box, error) {
+	var boxes []mp4Box
+	for offset := start; offset+8 <= end; {
+		header, err := readAt(r, offset, 8)
+		if err != nil {
+			return nil, err
+		}
+		size := int64(binary.BigEndian.Uint32(header[0:4]))
+		payload := offset + 8
+		switch size {
+		case 0:
+			size = end - offset
+		case 1:
+			large, err := readAt(r, offset+8, 8)
+			if err != nil {
+				return nil, err
+			}
+			size = int64(binary.BigEndian.Uint64(large))
+			payload += 8
+		}
+		if size < payload-offset || offset+size > end {
+			return nil, malformedAudio(AudioFormatM4a)
+		}
+		boxes = append(boxes, mp4Box{typ: string(header[4:8]), start: payload, end: offset + size})
+		offset += size
+	}
+	return boxes, nil
+}
+
+func mp4Child(r io.ReaderAt, parent mp4Box, typ string) (mp4Box, bool) {
+	boxes, err := mp4Children(r, parent.start, parent.end)
+	if err != nil {
+		return mp4Box{}, false
+	}
+	for _, box := range boxes {
+		if box.typ == typ {
+			return box, true
+		}
+	}
+	return mp4Box{}, false
+}
+
+// mp4Duration reads the timescale and duration of an mvhd or mdhd box.
+func mp4Duration(r io.ReaderAt, box mp4Box) time.Duration {
+	data, err := readAt(r, box.start, 32)
+	if err != nil {
+		return 0
+	}
+	var timescale, duration uint64
+	if data[0] == 1 {
+		timescale = uint64(binary.BigEndian.Uint32(data[20:24]))
+		duration = binary.BigEndian.Uint64(data[24:32])
+	} else {
+		timescale = uint64(binary.BigEndian.Uint32(data[12:16]))
+		duration = uint64(binary.BigEndian.Uint32(data[16:20]))
+	}
+	if timescale == 0 {
+		return 0
+	}
+	return seconds(float64(duration) / float64(timescale))
+}
+
+var mp4Codecs = map[string]string{
+	"mp4a": "aac",
+	"alac": "alac",
+	"Opus": "opus",
+	"fLaC": "flac",
+	".mp3": "mp3",
+	"ulaw": "mulaw",
+	"alaw": "alaw",
+	"lpcm": "pcm",
+	"sowt": "pcm",
+	"twos": "pcm",
+}
+
+func probeMp4(r io.ReaderAt, size int64) (*AudioInfo, error) {
+	top := mp4Box{start: 0, end: size}
+	moov, ok := mp4Child(r, top, "moov")
+	if !ok {
+		return nil, malformedAudio(AudioFormatM4a)
+	}
+
+	info := &AudioInfo{Format: AudioFormatM4a}
+	if mvhd, ok := mp4Child(r, moov, "mvhd"); ok {
+		info.Duration = mp4Duration(r, mvhd)
+	}
+
+	traks, err := mp4Children(r, moov.start, moov.end)
+	if err != nil {
+		return nil, malformedAudio(AudioFormatM4a)
+	}
+	for _, trak := range traks {
+		if trak.typ != "trak" {
+			continue
+		}
+		mdia, ok := mp4Child(r, trak, "mdia")
+		if !ok {
+			continue
+		}
+		hdlr, ok := mp4Child(r, mdia, "hdlr")
+		if !ok {
+			continue
+		}
+		if handler, err := readAt(r, hdlr.start+8, 4); err != nil || string(handler) != "soun" {
+			continue
+		}
+
+		if mdhd, ok := mp4Child(r, mdia, "mdhd"); ok {
+			if duration := mp4Duration(r, mdhd); duration > 0 {
+				info.Duration = duration
+			}
+		}
+		minf, ok := mp4Child(r, mdia, "minf")
+		if !ok {
+			return nil, malformedAudio(AudioFormatM4a)
+		}
+		stbl, ok := mp4Child(r, minf, "stbl")
+		if !ok {
+			return nil, malformedAudio(AudioFormatM4a)
+		}
+		stsd, ok := mp4Child(r, stbl, "stsd")
+		if !ok {
+			return nil, malformedAudio(AudioFormatM4a)
+		}
+		// The first sample entry follows the version, flags and entry count.
+		entry, err := readAt(r, stsd.start+8, 36)
+		if err != nil {
+			return nil, malformedAudio(AudioFormatM4a)
+		}
+		fourcc := string(entry[4:8])
+		info.Codec = mp4Codecs[fourcc]
+		if info.Codec == "" {
+			info.Codec = strings.TrimSpace(fourcc)
+		}
+		info.Channels = int(binary.BigEndian.Uint16(entry[24:26]))
+		info.SampleRate = int(binary.BigEndian.Uint16(entry[32:34]))
+		return info, nil
+	}
+	return nil, fmt.Errorf("%w: M4A file contains no audio track", ErrValidation)
+}
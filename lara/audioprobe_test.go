@@ -0,0 +1,365 @@
+package lara
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+)
+
+func le16(v uint16) []byte {
+	b := make([]byte, 2)
+	binary.LittleEndian.PutUint16(b, v)
+	return b
+}
+
+func le32(v uint32) []byte {
+	b := make([]byte, 4)
+	binary.LittleEndian.PutUint32(b, v)
+	return b
+}
+
+func be16(v uint16) []byte {
+	b := make([]byte, 2)
+	binary.BigEndian.PutUint16(b, v)
+	return b
+}
+
+func be32(v uint32) []byte {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, v)
+	return b
+}
+
+func join(parts ...[]byte) []byte {
+	return bytes.Join(parts, nil)
+}
+
+// testWav builds a WAV file with a fmt chunk and dataLen bytes of silence.
+func testWav(tag uint16, channels uint16, rate, byteRate uint32, dataLen int) []byte {
+	format := join(le16(tag), le16(channels), le32(rate), le32(byteRate), le16(4), le16(16))
+	body := join([]byte("WAVE"),
+		[]byte("LIST"), le32(3), []byte("abc\x00"), // odd-sized chunk, padded
+		[]byte("fmt "), le32(uint32(len(format))), format,
+		[]byte("data"), le32(uint32(dataLen)), make([]byte, dataLen))
+	return join([]byte("RIFF"), le32(uint32(len(body))), body)
+}
+
+// mp3Header is an MPEG 1 layer III frame header: 128 kbit/s, 44.1 kHz,
+// stereo, so every frame is 417 bytes long.
+var mp3Header = []byte{0xff, 0xfb, 0x90, 0x00}
+
+const mp3FrameLength = 417
+
+func testMp3Frame(payload []byte) []byte {
+	frame := make([]byte, mp3FrameLength)
+	copy(frame, mp3Header)
+	copy(frame[4:], payload)
+	return frame
+}
+
+func testMp3(frames int, first []byte) []byte {
+	var out []byte
+	if first != nil {
+		out = append(out, testMp3Frame(first)...)
+	}
+	for i := 0; i < frames; i++ {
+		out = append(out, testMp3Frame(nil)...)
+	}
+	return out
+}
+
+func testID3(size int) []byte {
+	return join([]byte("ID3\x03\x00\x00"), []byte{0, 0, byte(size >> 7 & 0x7f), byte(size & 0x7f)}, make([]byte, size))
+}
+
+func testFlacStreamInfo(rate uint64, channels uint64, samples uint64) []byte {
+	info := make([]byte, 34)
+	packed := rate<<44 | (channels-1)<<41 | 15<<36 | samples
+	binary.BigEndian.PutUint64(info[10:18], packed)
+	return info
+}
+
+func testFlac(rate, channels, samples uint64) []byte {
+	return join([]byte("fLaC"), []byte{0x80, 0, 0, 34}, testFlacStreamInfo(rate, channels, samples), make([]byte, 64))
+}
+
+func testOggPage(granule uint64, packet []byte) []byte {
+	var segments []byte
+	n := len(packet)
+	for ; n >= 255; n -= 255 {
+		segments = append(segments, 255)
+	}
+	segments = append(segments, byte(n))
+	header := join([]byte("OggS\x00\x02"), make([]byte, 8), le32(7), le32(0), le32(0), []byte{byte(len(segments))})
+	binary.LittleEndian.PutUint64(header[6:14], granule)
+	return join(header, segments, packet)
+}
+
+func testOgg(first []byte, lastGranule uint64) []byte {
+	return join(testOggPage(0, first), testOggPage(lastGranule, make([]byte, 100)))
+}
+
+func testVorbisHead(channels byte, rate, nominal uint32) []byte {
+	return join([]byte("\x01vorbis"), le32(0), []byte{channels}, le32(rate), le32(0), le32(nominal), le32(0), []byte{0xb8, 1})
+}
+
+func testOpusHead(channels byte, preSkip uint16, rate uint32) []byte {
+	return join([]byte("OpusHead\x01"), []byte{channels}, le16(preSkip), le32(rate), le16(0), []byte{0})
+}
+
+func testOggFlacHead(rate, channels uint64) []byte {
+	return join([]byte("\x7fFLAC\x01\x00"), be16(1), []byte("fLaC"), []byte{0x80, 0, 0, 34}, testFlacStreamInfo(rate, channels, 0))
+}
+
+func box(typ string, children ...[]byte) []byte {
+	payload := join(children...)
+	return join(be32(uint32(8+len(payload))), []byte(typ), payload)
+}
+
+// testMp4 builds an M4A file with a movie header and one track of the given handler.
+func testMp4(handler, fourcc string, timescale, duration uint32, channels, rate uint16) []byte {
+	mvhd := join(make([]byte, 12), be32(1000), be32(0), make([]byte, 80))
+	mdhd := join(make([]byte, 12), be32(timescale), be32(duration), make([]byte, 4))
+	hdlr := join(make([]byte, 8), []byte(handler), make([]byte, 13))
+	entry := join(be32(36), []byte(fourcc), make([]byte, 16), be16(channels), be16(16), make([]byte, 4), be16(rate), be16(0))
+	stsd := join(make([]byte, 4), be32(1), entry)
+	return join(
+		box("ftyp", []byte("M4A \x00\x00\x00\x00")),
+		box("moov",
+			box("mvhd", mvhd),
+			box("trak",
+				box("tkhd", make([]byte, 84)),
+				box("mdia",
+					box("mdhd", mdhd),
+					box("hdlr", hdlr),
+					box("minf", box("stbl", box("stsd", stsd))),
+				),
+			),
+		),
+		box("mdat", make([]byte, 64)),
+	)
+}
+
+func TestProbeAudio(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     []byte
+		want     AudioInfo
+		duration time.Duration
+	}{
+		{
+			name:     "wav pcm",
+			data:     testWav(1, 2, 8000, 32000, 64000),
+			want:     AudioInfo{Format: AudioFormatWav, Codec: "pcm", SampleRate: 8000, Channels: 2, BitRate: 256000},
+			duration: 2 * time.Second,
+		},
+		{
+			name:     "wav adpcm",
+			data:     testWav(2, 1, 8000, 4000, 2000),
+			want:     AudioInfo{Format: AudioFormatWav, Codec: "wav-0x0002", SampleRate: 8000, Channels: 1, BitRate: 32000},
+			duration: 500 * time.Millisecond,
+		},
+		{
+			name:     "mp3 cbr",
+			data:     testMp3(10, nil),
+			want:     AudioInfo{Format: AudioFormatMp3, Codec: "mp3", SampleRate: 44100, Channels: 2, BitRate: 128000},
+			duration: seconds(10 * mp3FrameLength * 8 / 128000.0),
+		},
+		{
+			name:     "mp3 xing",
+			data:     testMp3(3, join(make([]byte, 32), []byte("Xing"), be32(1), be32(1000))),
+			want:     AudioInfo{Format: AudioFormatMp3, Codec: "mp3", SampleRate: 44100, Channels: 2},
+			duration: seconds(1000 * 1152 / 44100.0),
+		},
+		{
+			name:     "mp3 vbri after id3",
+			data:     join(testID3(300), testMp3(3, join(make([]byte, 32), []byte("VBRI"), make([]byte, 10), be32(500)))),
+			want:     AudioInfo{Format: AudioFormatMp3, Codec: "mp3", SampleRate: 44100, Channels: 2},
+			duration: seconds(500 * 1152 / 44100.0),
+		},
+		{
+			name:     "flac",
+			data:     testFlac(48000, 2, 480000),
+			want:     AudioInfo{Format: AudioFormatFlac, Codec: "flac", SampleRate: 48000, Channels: 2},
+			duration: 10 * time.Second,
+		},
+		{
+			name:     "flac after id3",
+			data:     join(testID3(20), testFlac(44100, 1, 44100)),
+			want:     AudioInfo{Format: AudioFormatFlac, Codec: "flac", SampleRate: 44100, Channels: 1},
+			duration: time.Second,
+		},
+		{
+			name:     "ogg vorbis",
+			data:     testOgg(testVorbisHead(2, 44100, 96000), 441000),
+			want:     AudioInfo{Format: AudioFormatOgg, Codec: "vorbis", SampleRate: 44100, Channels: 2, BitRate: 96000},
+			duration: 10 * time.Second,
+		},
+		{
+			name:     "ogg opus",
+			data:     testOgg(testOpusHead(1, 312, 16000), 48000*3+312),
+			want:     AudioInfo{Format: AudioFormatOgg, Codec: "opus", SampleRate: 16000, Channels: 1},
+			duration: 3 * time.Second,
+		},
+		{
+			name:     "ogg flac",
+			data:     testOgg(testOggFlacHead(32000, 2), 64000),
+			want:     AudioInfo{Format: AudioFormatOgg, Codec: "flac", SampleRate: 32000, Channels: 2},
+			duration: 2 * time.Second,
+		},
+		{
+			name:     "ogg speex",
+			data:     testOgg([]byte("Speex   1.2"), 8000),
+			want:     AudioInfo{Format: AudioFormatOgg, Codec: "speex"},
+			duration: 0,
+		},
+		{
+			name:     "m4a aac",
+			data:     testMp4("soun", "mp4a", 44100, 44100*90, 2, 44100),
+			want:     AudioInfo{Format: AudioFormatM4a, Codec: "aac", SampleRate: 44100, Channels: 2},
+			duration: 90 * time.Second,
+		},
+		{
+			name:     "m4a alac",
+			data:     testMp4("soun", "alac", 1000, 1500, 1, 48000),
+			want:     AudioInfo{Format: AudioFormatM4a, Codec: "alac", SampleRate: 48000, Channels: 1},
+			duration: 1500 * time.Millisecond,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := ProbeAudio(bytes.NewReader(tt.data), int64(len(tt.data)))
+			if err != nil {
+				t.Fatalf("ProbeAudio: %v", err)
+			}
+			if diff := info.Duration - tt.duration; diff < -time.Millisecond || diff > time.Millisecond {
+				t.Errorf("duration %s, want %s", info.Duration, tt.duration)
+			}
+			if info.Size != int64(len(tt.data)) {
+				t.Errorf("size %d, want %d", info.Size, len(tt.data))
+			}
+			got := *info
+			got.Duration, got.Size = 0, 0
+			if tt.want.BitRate == 0 {
+				got.BitRate = 0 // derived from the size and duration
+			}
+			if got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProbeAudioMalformed(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"wav without data", testWav(1, 1, 8000, 8000, 0)[:12+8+4+8+16]},
+		{"wav with empty data", testWav(1, 1, 8000, 8000, 0)},
+		{"wav data before fmt", join([]byte("RIFF"), le32(16), []byte("WAVE"), []byte("data"), le32(4), make([]byte, 4))},
+		{"flac without streaminfo", join([]byte("fLaC"), []byte{0x84, 0, 0, 34}, make([]byte, 34))},
+		{"ogg truncated", testOgg(testVorbisHead(2, 44100, 0), 1)[:40]},
+		{"m4a without moov", box("ftyp", []byte("M4A \x00\x00\x00\x00"))},
+		{"m4a video only", testMp4("vide", "avc1", 1000, 1000, 0, 0)},
+		{"m4a bad box size", join(box("ftyp", []byte("M4A \x00\x00\x00\x00")), be32(4), []byte("moov"))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ProbeAudio(bytes.NewReader(tt.data), int64(len(tt.data)))
+			if !errors.Is(err, ErrValidation) {
+				t.Fatalf("got %v, want ErrValidation", err)
+			}
+		})
+	}
+}
+
+// TestProbeAudioCorrupt feeds every prefix of each sample, and random byte
+// flips of it, to the probe: it may fail but must not panic.
+func TestProbeAudioCorrupt(t *testing.T) {
+	samples := [][]byte{
+		testWav(0xfffe, 2, 8000, 32000, 100),
+		testMp3(2, join(make([]byte, 32), []byte("Xing"), be32(1), be32(1000))),
+		join(testID3(10), testMp3(2, join(make([]byte, 32), []byte("VBRI"), make([]byte, 10), be32(500)))),
+		testFlac(48000, 2, 480000),
+		testOgg(testVorbisHead(2, 44100, 96000), 441000),
+		testOgg(testOpusHead(1, 312, 16000), 48000),
+		testOgg(testOggFlacHead(32000, 2), 64000),
+		testMp4("soun", "mp4a", 44100, 44100, 2, 44100),
+	}
+
+	random := rand.New(rand.NewSource(1))
+	for _, sample := range samples {
+		for n := 0; n <= len(sample); n++ {
+			ProbeAudio(bytes.NewReader(sample[:n]), int64(n))
+		}
+		for i := 0; i < 500; i++ {
+			data := append([]byte(nil), sample...)
+			for flips := random.Intn(4) + 1; flips > 0; flips-- {
+				data[random.Intn(len(data))] = byte(random.Intn(256))
+			}
+			ProbeAudio(bytes.NewReader(data), int64(len(data)))
+		}
+	}
+
+	for i := 0; i < 200; i++ {
+		data := make([]byte, random.Intn(2048))
+		random.Read(data)
+		ProbeAudio(bytes.NewReader(data), int64(len(data)))
+	}
+}
+
+func TestProbeUploadFile(t *testing.T) {
+	unlimited := &AudioLimits{}
+	tests := []struct {
+		name    string
+		data    []byte
+		limits  *AudioLimits
+		wantErr bool
+	}{
+		{"empty", nil, unlimited, true},
+		{"malformed", testWav(1, 1, 8000, 8000, 0), unlimited, true},
+		{"unrecognized", []byte(strings.Repeat("not audio ", 10)), unlimited, false},
+		{"unlisted codec by default", testWav(2, 1, 8000, 4000, 2000), unlimited, false},
+		{"codec not accepted", testWav(2, 1, 8000, 4000, 2000), &AudioLimits{Codecs: []string{"pcm"}}, true},
+		{"codec accepted", testWav(1, 1, 8000, 8000, 2000), &AudioLimits{Codecs: []string{"pcm"}}, false},
+		{"too large", testWav(1, 1, 8000, 8000, 2000), &AudioLimits{MaxSize: 1000}, true},
+		{"unrecognized too large", make([]byte, 2000), &AudioLimits{MaxSize: 1000}, true},
+		{"too long", testFlac(8000, 1, 8000*120), &AudioLimits{MaxDuration: time.Minute}, true},
+		{"within limits", testFlac(8000, 1, 8000*30), &AudioLimits{MaxDuration: time.Minute, MaxSize: 1 << 20}, false},
+		{"long without limits", testFlac(8000, 1, 8000*3*3600), unlimited, false},
+	}
+
+	for _, tt := range tests {
+		for _, spooled := range []bool{false, true} {
+			var reader io.Reader = bytes.NewReader(tt.data)
+			if spooled {
+				reader = onlyReader{reader}
+			}
+			_, upload, cleanup, err := probeUploadFile(NewUploadFile(reader, "audio.wav"), tt.limits)
+			if tt.wantErr {
+				if !errors.Is(err, ErrValidation) {
+					t.Errorf("%s (spooled %t): got %v, want ErrValidation", tt.name, spooled, err)
+				}
+				continue
+			}
+			if err != nil {
+				t.Errorf("%s (spooled %t): %v", tt.name, spooled, err)
+				continue
+			}
+			// The upload must still hold the whole file.
+			content, err := io.ReadAll(upload.Reader)
+			cleanup()
+			if err != nil || !bytes.Equal(content, tt.data) {
+				t.Errorf("%s (spooled %t): upload reads %d bytes, want %d (%v)", tt.name, spooled, len(content), len(tt.data), err)
+			}
+		}
+	}
+}
@@ -1,5 +1,32 @@
 package lara
 
+import (
+	"context"
+	"fmt"
+)
+
+// Authenticator obtains a new session for the client. It is called whenever
+// there is no valid token and the refresh token cannot be used. AccessKey
+// and AuthToken implement it; custom implementations can, for instance,
+// fetch an access key from a secrets vault on every login, or exchange
+// credentials with an identity provider of their own for an AuthToken.
+type Authenticator interface {
+	Authenticate(ctx context.Context, client *AuthClient) (*AuthToken, error)
+}
+
+// AuthClient gives an Authenticator access to the Lara authentication endpoints.
+type AuthClient struct {
+	client *Client
+}
+
+// WithAccessKey signs in with an access key using HMAC challenge-response.
+func (a *AuthClient) WithAccessKey(ctx context.Context, accessKey *AccessKey) (*AuthToken, error) {
+	if accessKey == nil || accessKey.ID == "" || accessKey.Secret == "" {
+		return nil, fmt.Errorf("%w: access key ID and secret are required", ErrAuthentication)
+	}
+	return a.client.authenticateWithAccessKey(ctx, accessKey)
+}
+
 // AccessKey represents API key authentication credentials
 type AccessKey struct {
 	ID     string
@@ -14,6 +41,11 @@ func NewAccessKey(id, secret string) *AccessKey {
 	}
 }
 
+// Authenticate implements Authenticator
+func (k *AccessKey) Authenticate(ctx context.Context, client *AuthClient) (*AuthToken, error) {
+	return client.WithAccessKey(ctx, k)
+}
+
 // AuthToken represents JWT token authentication
 type AuthToken struct {
 	Token        string
@@ -27,3 +59,10 @@ func NewAuthToken(token, refreshToken string) *AuthToken {
 		RefreshToken: refreshToken,
 	}
 }
+
+// Authenticate implements Authenticator. The token itself is used until it
+// expires and is then renewed with the refresh token; a new session cannot be
+// started without other credentials, so this always fails.
+func (t *AuthToken) Authenticate(ctx context.Context, client *AuthClient) (*AuthToken, error) {
+	return nil, fmt.Errorf("%w: the auth token has expired and cannot be renewed", ErrAuthentication)
+}
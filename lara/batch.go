@@ -0,0 +1,434 @@
+package lara
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultBatchConcurrency = 4
+
+// Statuses of a file in a BatchManifest.
+const (
+	BatchStatusTranslated = "translated"
+	BatchStatusSkipped    = "skipped"
+	BatchStatusFailed     = "failed"
+)
+
+// BatchOptions configures DocumentsService.TranslateBatch and TranslateDirectory.
+type BatchOptions struct {
+	// Targets lists the target languages. Required.
+	Targets []string
+	// Source is the source language; nil lets Lara detect it.
+	Source *string
+	// OutputDir receives one subdirectory per target language, each mirroring
+	// the input tree. Required.
+	OutputDir string
+	// BaseDir is the root the input paths are mirrored from. TranslateDirectory
+	// sets it to the input directory; for TranslateBatch, when empty, outputs
+	// are named after the input's base name.
+	BaseDir string
+	// Extensions restricts TranslateDirectory to files with these extensions,
+	// e.g. ".docx", ".pdf"; when empty every regular, non-hidden file is translated.
+	Extensions []string
+	// Concurrency bounds how many files are uploaded, and how many translations
+	// are created and polled, at once. Defaults to 4.
+	Concurrency int
+	// ManifestPath is where the JSON manifest is written. Defaults to
+	// manifest.json in OutputDir.
+	ManifestPath string
+	// Force translates files again even when their output already exists.
+	Force bool
+	// Translate holds the options applied to every document.
+	Translate *DocumentTranslateOptions
+	// OnFileDone, if set, is called concurrently as each input file and target finishes.
+	OnFileDone func(result BatchFileResult)
+}
+
+// BatchManifest summarizes a batch translation.
+type BatchManifest struct {
+	StartedAt  time.Time         `json:"started_at"`
+	FinishedAt time.Time         `json:"finished_at"`
+	Files      []BatchFileResult `json:"files"`
+}
+
+// BatchFileResult is the outcome of translating one input file into one target language.
+type BatchFileResult struct {
+	Input           string `json:"input"`
+	Target          string `json:"target"`
+	Output          string `json:"output"`
+	Status          string `json:"status"`
+	DocumentID      string `json:"document_id,omitempty"`
+	TranslatedChars *int   `json:"translated_chars,omitempty"`
+	TotalChars      *int   `json:"total_chars,omitempty"`
+	Error           string `json:"error,omitempty"`
+}
+
+// TranslateDirectory translates every file under inputDir, see TranslateBatch.
+func (d *DocumentsService) TranslateDirectory(inputDir string, options *BatchOptions) (*BatchManifest, error) {
+	return d.TranslateDirectoryCtx(context.Background(), inputDir, options)
+}
+
+func (d *DocumentsService) TranslateDirectoryCtx(ctx context.Context, inputDir string, options *BatchOptions) (*BatchManifest, error) {
+	if options == nil {
+		return nil, fmt.Errorf("%w: batch options are required", ErrValidation)
+	}
+
+	outputDir, err := filepath.Abs(options.OutputDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve output directory: %w", err)
+	}
+
+	extensions := map[string]bool{}
+	for _, ext := range options.Extensions {
+		extensions[strings.ToLower(ext)] = true
+	}
+
+	var inputs []string
+	err = filepath.Walk(inputDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		hidden := path != inputDir && strings.HasPrefix(info.Name(), ".")
+		if info.IsDir() {
+			if abs, err := filepath.Abs(path); err == nil && abs == outputDir {
+				return filepath.SkipDir
+			}
+			if hidden {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if hidden || !info.Mode().IsRegular() {
+			return nil
+		}
+		if len(extensions) > 0 && !extensions[strings.ToLower(filepath.Ext(path))] {
+			return nil
+		}
+		inputs = append(inputs, path)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list input directory: %w", err)
+	}
+
+	batchOptions := *options
+	batchOptions.BaseDir = inputDir
+	return d.TranslateBatchCtx(ctx, inputs, &batchOptions)
+}
+
+// TranslateBatch translates the input files into every target language. At most
+// Concurrency files are uploaded at once, and at most Concurrency translations
+// are created and polled at once across all files and targets. Each file is
+// uploaded once for all of its targets. Outputs are written to OutputDir/<target>/<path relative to BaseDir>,
+// with the extension of the requested output format; a file whose output
+// already exists is skipped unless Force is set, so an interrupted batch can
+// simply be run again. Inputs outside BaseDir, and inputs that would be
+// written to the same output, are recorded as failed.
+//
+// The manifest is rewritten as each file finishes. Failures of individual files
+// are recorded in it; the returned error is only set when the batch could not
+// run, ctx ended or the manifest could not be written.
+func (d *DocumentsService) TranslateBatch(inputs []string, options *BatchOptions) (*BatchManifest, error) {
+	return d.TranslateBatchCtx(context.Background(), inputs, options)
+}
+
+func (d *DocumentsService) TranslateBatchCtx(ctx context.Context, inputs []string, options *BatchOptions) (*BatchManifest, error) {
+	if options == nil || len(options.Targets) == 0 || options.OutputDir == "" {
+		return nil, fmt.Errorf("%w: batch targets and output directory are required", ErrValidation)
+	}
+
+	if options.Translate != nil {
+		if err := validateOutputFormat(options.Translate.OutputFormat); err != nil {
+			return nil, err
+		}
+	}
+
+	concurrency := options.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultBatchConcurrency
+	}
+
+	manifestPath := options.ManifestPath
+	if manifestPath == "" {
+		manifestPath = filepath.Join(options.OutputDir, "manifest.json")
+	}
+
+	b := &batch{
+		service:      d,
+		options:      options,
+		jobs:         make(chan struct{}, concurrency),
+		manifestPath: manifestPath,
+		manifest:     &BatchManifest{StartedAt: time.Now().UTC(), Files: []BatchFileResult{}},
+		previous:     map[[2]string]BatchFileResult{},
+	}
+
+	// Skipped files keep the details recorded by the run that translated them.
+	if data, err := os.ReadFile(manifestPath); err == nil {
+		var previous BatchManifest
+		if json.Unmarshal(data, &previous) == nil {
+			for _, file := range previous.Files {
+				b.previous[[2]string{file.Input, file.Target}] = file
+			}
+		}
+	}
+
+	inputs, paths := b.plan(inputs)
+
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for _, input := range inputs {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(input string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			b.translateFile(ctx, input, paths[input])
+		}(input)
+	}
+	wg.Wait()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.manifest.FinishedAt = time.Now().UTC()
+	if err := b.writeManifest(); err != nil {
+		return b.manifest, err
+	}
+	if b.manifestErr != nil {
+		return b.manifest, b.manifestErr
+	}
+	return b.manifest, ctx.Err()
+}
+
+// batch holds the state shared by the workers of a batch translation.
+type batch struct {
+	service      *DocumentsService
+	options      *BatchOptions
+	manifestPath string
+	previous     map[[2]string]BatchFileResult
+	// jobs bounds the translations in flight across every file and target.
+	jobs chan struct{}
+
+	mu          sync.Mutex // guards manifest and manifestErr
+	manifest    *BatchManifest
+	manifestErr error
+}
+
+// plan maps each input to its output path relative to the target directories.
+// Inputs outside BaseDir, and inputs whose outputs would overwrite each other,
+// are recorded as failed and left out of the returned inputs.
+func (b *batch) plan(inputs []string) ([]string, map[string]string) {
+	paths := map[string]string{}
+	inputsByPath := map[string][]string{}
+	var order []string
+	var results []BatchFileResult
+	for _, input := range inputs {
+		if _, ok := paths[input]; ok {
+			continue
+		}
+
+		rel := filepath.Base(input)
+		if b.options.BaseDir != "" {
+			r, err := filepath.Rel(b.options.BaseDir, input)
+			if err != nil || r == ".." || strings.HasPrefix(r, ".."+string(filepath.Separator)) {
+				err = fmt.Errorf("%w: %s is not inside the base directory %s", ErrValidation, input, b.options.BaseDir)
+				results = append(results, b.failed(input, b.options.Targets, nil, err)...)
+				continue
+			}
+			rel = r
+		}
+
+		path := OutputFilename(rel, b.outputFormat())
+		paths[input] = path
+		if len(inputsByPath[path]) == 0 {
+			order = append(order, path)
+		}
+		inputsByPath[path] = append(inputsByPath[path], input)
+	}
+
+	var planned []string
+	for _, path := range order {
+		inputs := inputsByPath[path]
+		if len(inputs) == 1 {
+			planned = append(planned, inputs[0])
+			continue
+		}
+
+		outputs := map[string]string{}
+		for _, target := range b.options.Targets {
+			outputs[target] = filepath.Join(b.options.OutputDir, target, path)
+		}
+		err := fmt.Errorf("%w: %s would be written by each of %s; set BaseDir to keep their directories apart", ErrValidation, path, strings.Join(inputs, ", "))
+		for _, input := range inputs {
+			results = append(results, b.failed(input, b.options.Targets, outputs, err)...)
+		}
+	}
+
+	if len(results) > 0 {
+		b.record(results)
+	}
+	return planned, paths
+}
+
+func (b *batch) translateFile(ctx context.Context, input, path string) {
+	var pending []string
+	outputs := map[string]string{}
+	var results []BatchFileResult
+	for _, target := range b.options.Targets {
+		if _, ok := outputs[target]; ok {
+			continue
+		}
+		output := filepath.Join(b.options.OutputDir, target, path)
+		outputs[target] = output
+
+		if !b.options.Force {
+			if info, err := os.Stat(output); err == nil && info.Mode().IsRegular() {
+				result := BatchFileResult{Input: input, Target: target, Output: output}
+				if previous, ok := b.previous[[2]string{input, target}]; ok {
+					result = previous
+					result.Error = ""
+				}
+				result.Output = output
+				result.Status = BatchStatusSkipped
+				results = append(results, result)
+				continue
+			}
+		}
+		pending = append(pending, target)
+	}
+
+	if len(pending) > 0 {
+		results = append(results, b.translateTargets(ctx, input, pending, outputs)...)
+	}
+	b.record(results)
+}
+
+// outputFormat returns the output format requested for every document.
+func (b *batch) outputFormat() DocumentOutputFormat {
+	if b.options.Translate == nil {
+		return OutputFormatOriginal
+	}
+	return b.options.Translate.OutputFormat
+}
+
+func (b *batch) translateTargets(ctx context.Context, input string, targets []string, outputs map[string]string) []BatchFileResult {
+	file, err := os.Open(input)
+	if err != nil {
+		return b.failed(input, targets, outputs, fmt.Errorf("failed to open document file: %w", err))
+	}
+	defer file.Close()
+
+	translated, err := b.service.translateMulti(ctx, NewUploadFile(file, filepath.Base(input)), b.options.Source, targets, b.options.Translate, b.jobs)
+	if err != nil {
+		return b.failed(input, targets, outputs, err)
+	}
+
+	results := make([]BatchFileResult, 0, len(targets))
+	for _, target := range targets {
+		result := BatchFileResult{Input: input, Target: target, Output: outputs[target], Status: BatchStatusTranslated}
+
+		r := translated[target]
+		if r.Document != nil {
+			result.DocumentID = r.Document.ID
+			result.TranslatedChars = r.Document.TranslatedChars
+			result.TotalChars = r.Document.TotalChars
+		}
+
+		err := r.Err
+		if err == nil {
+			err = b.download(ctx, r, result.Output)
+		}
+		if err != nil {
+			result.Status = BatchStatusFailed
+			result.Error = err.Error()
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+func (b *batch) download(ctx context.Context, result *DocumentResult, output string) error {
+	reader, err := result.Download(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Closing a journaled output records it as completed, which may fail.
+	err = writeFileAtomic(output, reader)
+	if closeErr := reader.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// failed returns a failed result for each target of input.
+func (b *batch) failed(input string, targets []string, outputs map[string]string, err error) []BatchFileResult {
+	results := make([]BatchFileResult, 0, len(targets))
+	seen := map[string]bool{}
+	for _, target := range targets {
+		if seen[target] {
+			continue
+		}
+		seen[target] = true
+		results = append(results, BatchFileResult{
+			Input:  input,
+			Target: target,
+			Output: outputs[target],
+			Status: BatchStatusFailed,
+			Error:  err.Error(),
+		})
+	}
+	return results
+}
+
+// record adds results to the manifest and rewrites it.
+func (b *batch) record(results []BatchFileResult) {
+	if b.options.OnFileDone != nil {
+		for _, result := range results {
+			b.options.OnFileDone(result)
+		}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.manifest.Files = append(b.manifest.Files, results...)
+	sort.SliceStable(b.manifest.Files, func(i, j int) bool {
+		a, c := b.manifest.Files[i], b.manifest.Files[j]
+		if a.Input != c.Input {
+			return a.Input < c.Input
+		}
+		return a.Target < c.Target
+	})
+
+	if err := b.writeManifest(); err != nil && b.manifestErr == nil {
+		b.manifestErr = err
+	}
+}
+
+func (b *batch) writeManifest() error {
+	data, err := json.MarshalIndent(b.manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+	if err := writeFileAtomic(b.manifestPath, bytes.NewReader(data)); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,209 @@
+package lara
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeInputs creates the named files under dir and returns their paths.
+func writeInputs(t *testing.T, dir string, names ...string) []string {
+	var paths []string
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(name), 0644); err != nil {
+			t.Fatal(err)
+		}
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+// statuses maps "input target" to the status of each file in manifest.
+func statuses(manifest *BatchManifest) map[string]string {
+	result := map[string]string{}
+	for _, file := range manifest.Files {
+		result[filepath.Base(file.Input)+" "+file.Target] = file.Status
+	}
+	return result
+}
+
+func TestTranslateDirectorySkipAndForce(t *testing.T) {
+	api := newDocumentAPI(t)
+	documents := newTestDocuments(t, api)
+	inputDir, outputDir := t.TempDir(), t.TempDir()
+	writeInputs(t, inputDir, "a.docx", "sub/b.docx", ".hidden.docx", "notes.txt")
+
+	options := &BatchOptions{
+		Targets:    []string{"it-IT", "fr-FR"},
+		OutputDir:  outputDir,
+		Extensions: []string{".docx"},
+		Translate:  &DocumentTranslateOptions{DocumentWaitOptions: fastDocumentWait},
+	}
+	run := func() *BatchManifest {
+		manifest, err := documents.TranslateDirectory(inputDir, options)
+		if err != nil {
+			t.Fatalf("TranslateDirectory: %v", err)
+		}
+		return manifest
+	}
+
+	manifest := run()
+	want := map[string]string{"a.docx it-IT": "translated", "a.docx fr-FR": "translated", "b.docx it-IT": "translated", "b.docx fr-FR": "translated"}
+	if got := statuses(manifest); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("first run %v, want %v", got, want)
+	}
+	if uploads, creates, _ := api.counts(); uploads != 2 || creates != 4 {
+		t.Errorf("%d uploads and %d creates, want 2 and 4", uploads, creates)
+	}
+	ids := map[string]string{}
+	for _, file := range manifest.Files {
+		data, err := os.ReadFile(file.Output)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != file.DocumentID+":"+file.Target {
+			t.Errorf("%s holds %q, want the output of %s", file.Output, data, file.DocumentID)
+		}
+		ids[file.Output] = file.DocumentID
+	}
+	if _, err := os.Stat(filepath.Join(outputDir, "fr-FR", "sub", "b.docx")); err != nil {
+		t.Errorf("the input tree is not mirrored: %v", err)
+	}
+
+	// A second run skips every file and keeps what the first run recorded.
+	manifest = run()
+	for _, file := range manifest.Files {
+		if file.Status != BatchStatusSkipped || file.DocumentID != ids[file.Output] {
+			t.Errorf("second run: %+v, want skipped with document %s", file, ids[file.Output])
+		}
+	}
+	if len(api.creates()) != 4 {
+		t.Errorf("the second run created %d documents", len(api.creates())-4)
+	}
+	data, err := os.ReadFile(filepath.Join(outputDir, "manifest.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var written BatchManifest
+	if err := json.Unmarshal(data, &written); err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(statuses(&written)) != fmt.Sprint(statuses(manifest)) {
+		t.Errorf("manifest.json %v does not match the returned manifest", statuses(&written))
+	}
+
+	// Only missing outputs are translated again.
+	if err := os.Remove(filepath.Join(outputDir, "it-IT", "a.docx")); err != nil {
+		t.Fatal(err)
+	}
+	manifest = run()
+	want = map[string]string{"a.docx it-IT": "translated", "a.docx fr-FR": "skipped", "b.docx it-IT": "skipped", "b.docx fr-FR": "skipped"}
+	if got := statuses(manifest); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("after removing an output %v, want %v", got, want)
+	}
+	if len(api.creates()) != 5 {
+		t.Errorf("%d creates, want 5", len(api.creates()))
+	}
+
+	options.Force = true
+	manifest = run()
+	for _, file := range manifest.Files {
+		if file.Status != BatchStatusTranslated {
+			t.Errorf("with Force: %+v", file)
+		}
+	}
+	if len(api.creates()) != 9 {
+		t.Errorf("%d creates, want 9", len(api.creates()))
+	}
+}
+
+func TestTranslateBatchOutputPaths(t *testing.T) {
+	api := newDocumentAPI(t)
+	documents := newTestDocuments(t, api)
+	dir := t.TempDir()
+	inputs := writeInputs(t, dir, "a/x.docx", "b/x.docx", "c/y.docx")
+
+	manifest, err := documents.TranslateBatch(inputs, &BatchOptions{
+		Targets:   []string{"it-IT"},
+		OutputDir: filepath.Join(dir, "out"),
+		Translate: &DocumentTranslateOptions{DocumentWaitOptions: fastDocumentWait},
+	})
+	if err != nil {
+		t.Fatalf("TranslateBatch: %v", err)
+	}
+	for _, file := range manifest.Files {
+		want := BatchStatusTranslated
+		if strings.HasSuffix(file.Input, "x.docx") {
+			want = BatchStatusFailed
+		}
+		if file.Status != want {
+			t.Errorf("%s: %s (%s), want %s", file.Input, file.Status, file.Error, want)
+		}
+	}
+	if len(manifest.Files) != 3 || len(api.creates()) != 1 {
+		t.Errorf("%d results and %d creates, want 3 and 1", len(manifest.Files), len(api.creates()))
+	}
+
+	// With BaseDir both are kept apart, and inputs outside it are rejected.
+	outside := writeInputs(t, t.TempDir(), "z.docx")
+	manifest, err = documents.TranslateBatch(append(inputs, outside...), &BatchOptions{
+		Targets:   []string{"it-IT"},
+		OutputDir: filepath.Join(dir, "out"),
+		BaseDir:   dir,
+		Translate: &DocumentTranslateOptions{DocumentWaitOptions: fastDocumentWait},
+	})
+	if err != nil {
+		t.Fatalf("TranslateBatch: %v", err)
+	}
+	for _, file := range manifest.Files {
+		want := BatchStatusTranslated
+		if file.Input == outside[0] {
+			want = BatchStatusFailed
+		}
+		if file.Status != want {
+			t.Errorf("%s: %s (%s), want %s", file.Input, file.Status, file.Error, want)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "out", "it-IT", "a", "x.docx")); err != nil {
+		t.Error(err)
+	}
+	if _, err := os.Stat(filepath.Join(filepath.Dir(dir), "out", "it-IT", "z.docx")); !errors.Is(err, os.ErrNotExist) {
+		t.Error("an input outside BaseDir was written outside OutputDir")
+	}
+}
+
+func TestTranslateBatchConcurrency(t *testing.T) {
+	api := newDocumentAPI(t)
+	documents := newTestDocuments(t, api)
+	dir := t.TempDir()
+	inputs := writeInputs(t, dir, "1.docx", "2.docx", "3.docx", "4.docx", "5.docx", "6.docx")
+
+	manifest, err := documents.TranslateBatch(inputs, &BatchOptions{
+		Targets:     []string{"it-IT", "fr-FR", "de-DE"},
+		OutputDir:   filepath.Join(dir, "out"),
+		Concurrency: 2,
+		Translate:   &DocumentTranslateOptions{DocumentWaitOptions: fastDocumentWait},
+	})
+	if err != nil {
+		t.Fatalf("TranslateBatch: %v", err)
+	}
+	for _, file := range manifest.Files {
+		if file.Status != BatchStatusTranslated {
+			t.Errorf("%s %s: %s", file.Input, file.Target, file.Error)
+		}
+	}
+	if len(api.creates()) != 18 {
+		t.Errorf("%d creates, want 18", len(api.creates()))
+	}
+	if _, _, maxInFlight := api.counts(); maxInFlight > 2 {
+		t.Errorf("%d translations were in flight at once, want at most 2", maxInFlight)
+	}
+}
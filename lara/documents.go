@@ -1,8 +1,12 @@
 package lara
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
+	"os"
+	"sync"
 	"time"
 )
 
@@ -19,65 +23,157 @@ func newDocumentsService(client *Client, s3Client *S3Client) *DocumentsService {
 }
 
 func (d *DocumentsService) Upload(filePath, filename, source *string, target string) (*Document, error) {
+	return d.UploadCtx(context.Background(), filePath, filename, source, target)
+}
+
+func (d *DocumentsService) UploadCtx(ctx context.Context, filePath, filename, source *string, target string) (*Document, error) {
 	options := &DocumentUploadOptions{}
-	return d.UploadWithOptions(filePath, filename, source, target, options)
+	return d.UploadWithOptionsCtx(ctx, filePath, filename, source, target, options)
 }
 
 func (d *DocumentsService) UploadWithOptions(filePath, filename, source *string, target string, options *DocumentUploadOptions) (*Document, error) {
+	return d.UploadWithOptionsCtx(context.Background(), filePath, filename, source, target, options)
+}
+
+func (d *DocumentsService) UploadWithOptionsCtx(ctx context.Context, filePath, filename, source *string, target string, options *DocumentUploadOptions) (*Document, error) {
+	file, err := os.Open(*filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open document file: %w", err)
+	}
+	defer file.Close()
+
+	return d.UploadFromReader(ctx, NewUploadFile(file, *filename), source, target, options)
+}
+
+// UploadFromReader uploads a document whose content does not need to be on disk.
+// If the translation was created but could not be recorded in the journal,
+// the document is returned along with a *JournalError.
+func (d *DocumentsService) UploadFromReader(ctx context.Context, file *UploadFile, source *string, target string, options *DocumentUploadOptions) (*Document, error) {
+	document, _, err := d.upload(ctx, file, source, target, options, "")
+	return document, err
+}
+
+// upload uploads file and creates its translation, recording both steps in
+// the journal set in options, if any.
+func (d *DocumentsService) upload(ctx context.Context, file *UploadFile, source *string, target string, options *DocumentUploadOptions, outputFormat DocumentOutputFormat) (*Document, *JournalEntry, error) {
+	if err := validateOutputFormat(outputFormat); err != nil {
+		return nil, nil, err
+	}
+
+	s3Key, err := d.uploadFile(ctx, file)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	entry := newDocumentEntry(s3Key, file.Filename, source, target, options, outputFormat)
+	document, err := d.start(ctx, entry, journalOf(options), passwordOf(options))
+	return document, entry, err
+}
+
+// uploadFile uploads file to S3 and returns its key.
+func (d *DocumentsService) uploadFile(ctx context.Context, file *UploadFile) (string, error) {
 	params := map[string]string{
-		"filename": *filename,
+		"filename": file.Filename,
 	}
 
 	var uploadResponse struct {
 		URL    string         `json:"url"`
 		Fields s3UploadFields `json:"fields"`
 	}
-	err := d.client.Get("/v2/documents/upload-url", params, nil, &uploadResponse)
+	err := d.client.Get(ctx, "/v2/documents/upload-url", params, nil, &uploadResponse)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get upload URL: %w", err)
+		return "", fmt.Errorf("failed to get upload URL: %w", err)
 	}
 
-	err = d.s3Client.Upload(uploadResponse.URL, uploadResponse.Fields, *filePath)
+	err = d.s3Client.UploadReader(ctx, uploadResponse.URL, uploadResponse.Fields, file)
 	if err != nil {
-		return nil, fmt.Errorf("failed to upload file to S3: %w", err)
+		return "", fmt.Errorf("failed to upload file to S3: %w", err)
 	}
 
-	body := map[string]interface{}{
-		"s3key":  uploadResponse.Fields["key"],
-		"target": target,
-	}
+	return uploadResponse.Fields["key"], nil
+}
 
-	if source != nil {
-		body["source"] = *source
+// newDocumentEntry describes the translation of an uploaded file into target.
+func newDocumentEntry(s3Key, filename string, source *string, target string, options *DocumentUploadOptions, outputFormat DocumentOutputFormat) *JournalEntry {
+	entry := &JournalEntry{
+		Kind:         JournalKindDocument,
+		S3Key:        s3Key,
+		Filename:     filename,
+		Source:       source,
+		Target:       target,
+		Options:      map[string]interface{}{},
+		OutputFormat: outputFormat,
+		Status:       JournalStatusPending,
 	}
 
 	if options != nil {
 		if len(options.AdaptTo) > 0 {
-			body["adapt_to"] = options.AdaptTo
+			entry.Options["adapt_to"] = options.AdaptTo
 		}
 		if len(options.Glossaries) > 0 {
-			body["glossaries"] = options.Glossaries
+			entry.Options["glossaries"] = options.Glossaries
 		}
 		if options.Style != "" {
-			body["style"] = options.Style
+			entry.Options["style"] = options.Style
 		}
 		if options.ExtractionParams != nil {
-			body["extraction_params"] = options.ExtractionParams
+			entry.Options["extraction_params"] = options.ExtractionParams
 		}
-		if options.Password != nil {
-			body["password"] = *options.Password
+		entry.Protected = options.Password != nil
+		entry.NoTrace = options.NoTrace != nil && *options.NoTrace
+	}
+
+	return entry
+}
+
+func journalOf(options *DocumentUploadOptions) *Journal {
+	if options == nil {
+		return nil
+	}
+	return options.Journal
+}
+
+func passwordOf(options *DocumentUploadOptions) *string {
+	if options == nil {
+		return nil
+	}
+	return options.Password
+}
+
+// start creates the translation described by entry, recording it in journal
+// before and after when journal is not nil. If the translation was created
+// but could not be recorded, the document is returned along with a
+// *JournalError, so that it is not lost and paid for again.
+func (d *DocumentsService) start(ctx context.Context, entry *JournalEntry, journal *Journal, password *string) (*Document, error) {
+	if journal != nil {
+		if err := journal.record(entry); err != nil {
+			return nil, err
 		}
 	}
 
-	var headers map[string]string
-	if options != nil && options.NoTrace != nil && *options.NoTrace {
-		headers = map[string]string{
-			"X-No-Trace": "true",
+	document, err := d.create(ctx, entry, password)
+	if err != nil {
+		return nil, err
+	}
+
+	entry.ID = document.ID
+	if journal != nil {
+		if err := journal.record(entry); err != nil {
+			return document, &JournalError{ID: document.ID, Err: err}
 		}
 	}
+	return document, nil
+}
+
+// create starts the translation of a file already uploaded to S3.
+func (d *DocumentsService) create(ctx context.Context, entry *JournalEntry, password *string) (*Document, error) {
+	body := entry.requestBody()
+	if password != nil {
+		body["password"] = *password
+	}
 
 	var document Document
-	err = d.client.Post("/v2/documents", body, nil, headers, &document)
+	err := d.client.Post(ctx, "/v2/documents", body, nil, entry.headers(), &document)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create document: %w", err)
 	}
@@ -86,8 +182,12 @@ func (d *DocumentsService) UploadWithOptions(filePath, filename, source *string,
 }
 
 func (d *DocumentsService) Status(id string) (*Document, error) {
+	return d.StatusCtx(context.Background(), id)
+}
+
+func (d *DocumentsService) StatusCtx(ctx context.Context, id string) (*Document, error) {
 	var document Document
-	err := d.client.Get(fmt.Sprintf("/v2/documents/%s", id), nil, nil, &document)
+	err := d.client.Get(ctx, fmt.Sprintf("/v2/documents/%s", id), nil, nil, &document)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get document status: %w", err)
 	}
@@ -96,89 +196,415 @@ func (d *DocumentsService) Status(id string) (*Document, error) {
 }
 
 func (d *DocumentsService) Download(id string) (io.ReadCloser, error) {
-	return d.DownloadWithOptions(id, nil)
+	return d.DownloadCtx(context.Background(), id)
+}
+
+func (d *DocumentsService) DownloadCtx(ctx context.Context, id string) (io.ReadCloser, error) {
+	return d.DownloadWithOptionsCtx(ctx, id, nil)
 }
 
 func (d *DocumentsService) DownloadWithOptions(id string, options *DocumentDownloadOptions) (io.ReadCloser, error) {
+	return d.DownloadWithOptionsCtx(context.Background(), id, options)
+}
+
+func (d *DocumentsService) DownloadWithOptionsCtx(ctx context.Context, id string, options *DocumentDownloadOptions) (io.ReadCloser, error) {
+	downloadURL, err := d.downloadURL(ctx, id, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.s3Client.Download(ctx, downloadURL)
+}
+
+// DownloadToFile downloads the translated document to path. The file appears
+// atomically once its length has been checked, and an interrupted transfer is
+// resumed with Range requests, even by a later call for the same path. When
+// path is a directory, the file is saved under the name suggested by the
+// server, with the extension of the requested output format. onProgress may
+// be nil.
+func (d *DocumentsService) DownloadToFile(id, path string, options *DocumentDownloadOptions, onProgress DownloadProgressFunc) (*DownloadedFile, error) {
+	return d.DownloadToFileCtx(context.Background(), id, path, options, onProgress)
+}
+
+func (d *DocumentsService) DownloadToFileCtx(ctx context.Context, id, path string, options *DocumentDownloadOptions, onProgress DownloadProgressFunc) (*DownloadedFile, error) {
+	downloadURL, err := d.downloadURL(ctx, id, options)
+	if err != nil {
+		return nil, err
+	}
+
+	var extension string
+	if options != nil {
+		extension = options.OutputFormat.Extension()
+	}
+	return d.s3Client.downloadToFile(ctx, downloadURL, path, extension, onProgress)
+}
+
+func (d *DocumentsService) downloadURL(ctx context.Context, id string, options *DocumentDownloadOptions) (string, error) {
 	params := map[string]string{}
 	if options != nil && options.OutputFormat != "" {
-		params["output_format"] = options.OutputFormat
+		if err := validateOutputFormat(options.OutputFormat); err != nil {
+			return "", err
+		}
+		params["output_format"] = string(options.OutputFormat)
 	}
 
 	var downloadResponse struct {
 		URL string `json:"url"`
 	}
-	err := d.client.Get(fmt.Sprintf("/v2/documents/%s/download-url", id), params, nil, &downloadResponse)
+	err := d.client.Get(ctx, fmt.Sprintf("/v2/documents/%s/download-url", id), params, nil, &downloadResponse)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get download URL: %w", err)
+		return "", fmt.Errorf("failed to get download URL: %w", err)
 	}
 
-	return d.s3Client.Download(downloadResponse.URL)
+	return downloadResponse.URL, nil
 }
 
 func (d *DocumentsService) Translate(filePath, filename, source *string, target string) (io.ReadCloser, error) {
-	return d.TranslateWithOptions(filePath, filename, source, target, nil)
+	return d.TranslateCtx(context.Background(), filePath, filename, source, target)
+}
+
+func (d *DocumentsService) TranslateCtx(ctx context.Context, filePath, filename, source *string, target string) (io.ReadCloser, error) {
+	return d.TranslateWithOptionsCtx(ctx, filePath, filename, source, target, nil)
 }
 
 func (d *DocumentsService) TranslateWithOptions(filePath, filename, source *string, target string, options *DocumentTranslateOptions) (io.ReadCloser, error) {
-	uploadOptions := &DocumentUploadOptions{}
+	return d.TranslateWithOptionsCtx(context.Background(), filePath, filename, source, target, options)
+}
 
-	if options != nil {
-		uploadOptions.AdaptTo = options.AdaptTo
-		uploadOptions.Glossaries = options.Glossaries
-		uploadOptions.NoTrace = options.NoTrace
-		uploadOptions.Style = options.Style
-		uploadOptions.ExtractionParams = options.ExtractionParams
-		uploadOptions.Password = options.Password
+func (d *DocumentsService) TranslateWithOptionsCtx(ctx context.Context, filePath, filename, source *string, target string, options *DocumentTranslateOptions) (io.ReadCloser, error) {
+	file, err := os.Open(*filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open document file: %w", err)
 	}
+	defer file.Close()
 
-	document, err := d.UploadWithOptions(filePath, filename, source, target, uploadOptions)
-	if err != nil {
+	return d.TranslateFromReader(ctx, NewUploadFile(file, *filename), source, target, options)
+}
+
+// TranslateFromReader uploads, waits for and downloads the translation of a
+// document whose content does not need to be on disk. If the translation was
+// created but could not be recorded in the journal, it returns a
+// *JournalError carrying the document ID without waiting for it.
+func (d *DocumentsService) TranslateFromReader(ctx context.Context, file *UploadFile, source *string, target string, options *DocumentTranslateOptions) (io.ReadCloser, error) {
+	uploadOptions, outputFormat, waitOptions := splitTranslateOptions(options)
+
+	document, entry, err := d.upload(ctx, file, source, target, uploadOptions, outputFormat)
+	if document == nil {
 		return nil, fmt.Errorf("failed to upload document: %w", err)
 	}
+	if err != nil {
+		return nil, err
+	}
+
+	journal := journalOf(uploadOptions)
+	if _, err := d.await(ctx, document, entry, journal, waitOptions); err != nil {
+		return nil, err
+	}
+	return d.open(ctx, entry, journal)
+}
+
+// DocumentResult is the outcome of translating a document into one of the
+// targets of TranslateMulti. If the translation was created but could not be
+// recorded in the journal, Document is set and Err is a *JournalError.
+type DocumentResult struct {
+	Document *Document
+	Err      error
+	open     func(ctx context.Context) (io.ReadCloser, error)
+}
+
+// Download streams the translated document, or returns Err if the translation failed.
+func (r *DocumentResult) Download(ctx context.Context) (io.ReadCloser, error) {
+	if r.Err != nil {
+		return nil, r.Err
+	}
+	return r.open(ctx)
+}
+
+// TranslateMulti uploads a document once and translates it into every target
+// language, waiting for the translations concurrently. The result for each
+// target carries its own error; the returned error is only set when the
+// upload itself fails. OnProgress, if set, is called concurrently.
+func (d *DocumentsService) TranslateMulti(filePath, filename, source *string, targets []string, options *DocumentTranslateOptions) (map[string]*DocumentResult, error) {
+	return d.TranslateMultiCtx(context.Background(), filePath, filename, source, targets, options)
+}
 
-	document, err = d.waitForTranslation(document)
+func (d *DocumentsService) TranslateMultiCtx(ctx context.Context, filePath, filename, source *string, targets []string, options *DocumentTranslateOptions) (map[string]*DocumentResult, error) {
+	file, err := os.Open(*filePath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to wait for translation: %w", err)
+		return nil, fmt.Errorf("failed to open document file: %w", err)
 	}
+	defer file.Close()
 
-	if document.Status == DocumentStatusError {
-		errorMsg := "translation failed"
-		if document.ErrorReason != nil {
-			errorMsg = *document.ErrorReason
+	return d.TranslateMultiFromReader(ctx, NewUploadFile(file, *filename), source, targets, options)
+}
+
+// TranslateMultiFromReader is like TranslateMulti for a document whose content does not need to be on disk.
+func (d *DocumentsService) TranslateMultiFromReader(ctx context.Context, file *UploadFile, source *string, targets []string, options *DocumentTranslateOptions) (map[string]*DocumentResult, error) {
+	return d.translateMulti(ctx, file, source, targets, options, nil)
+}
+
+// translateMulti implements TranslateMultiFromReader. When limit is not nil,
+// each target holds a slot of it while its translation is created and polled,
+// so that callers sharing limit bound the number of jobs in flight.
+func (d *DocumentsService) translateMulti(ctx context.Context, file *UploadFile, source *string, targets []string, options *DocumentTranslateOptions, limit chan struct{}) (map[string]*DocumentResult, error) {
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("%w: at least one target language is required", ErrValidation)
+	}
+
+	uploadOptions, outputFormat, waitOptions := splitTranslateOptions(options)
+	journal := journalOf(uploadOptions)
+	if err := validateOutputFormat(outputFormat); err != nil {
+		return nil, err
+	}
+
+	s3Key, err := d.uploadFile(ctx, file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload document: %w", err)
+	}
+
+	results := make(map[string]*DocumentResult, len(targets))
+	var wg sync.WaitGroup
+	for _, target := range targets {
+		if _, ok := results[target]; ok {
+			continue
 		}
-		return nil, fmt.Errorf("document translation failed: %s", errorMsg)
+		result := &DocumentResult{}
+		results[target] = result
+
+		wg.Add(1)
+		go func(target string, result *DocumentResult) {
+			defer wg.Done()
+
+			if limit != nil {
+				select {
+				case limit <- struct{}{}:
+				case <-ctx.Done():
+					result.Err = ctx.Err()
+					return
+				}
+				defer func() { <-limit }()
+			}
+
+			entry := newDocumentEntry(s3Key, file.Filename, source, target, uploadOptions, outputFormat)
+			document, err := d.start(ctx, entry, journal, uploadOptions.Password)
+			if err != nil {
+				result.Document, result.Err = document, err
+				return
+			}
+
+			result.Document, result.Err = d.await(ctx, document, entry, journal, waitOptions)
+			result.open = func(ctx context.Context) (io.ReadCloser, error) {
+				return d.open(ctx, entry, journal)
+			}
+		}(target, result)
 	}
+	wg.Wait()
 
-	downloadOptions := &DocumentDownloadOptions{}
-	if options != nil && options.OutputFormat != "" {
-		downloadOptions.OutputFormat = options.OutputFormat
+	return results, nil
+}
+
+// splitTranslateOptions separates the upload, download and wait parts of options.
+func splitTranslateOptions(options *DocumentTranslateOptions) (*DocumentUploadOptions, DocumentOutputFormat, *DocumentWaitOptions) {
+	if options == nil {
+		return &DocumentUploadOptions{}, "", nil
+	}
+
+	uploadOptions := &DocumentUploadOptions{}
+	uploadOptions.AdaptTo = options.AdaptTo
+	uploadOptions.Glossaries = options.Glossaries
+	uploadOptions.NoTrace = options.NoTrace
+	uploadOptions.Style = options.Style
+	uploadOptions.ExtractionParams = options.ExtractionParams
+	uploadOptions.Password = options.Password
+	uploadOptions.Journal = options.Journal
+
+	return uploadOptions, options.OutputFormat, &options.DocumentWaitOptions
+}
+
+// await waits for the translation of document and records a failure in journal.
+func (d *DocumentsService) await(ctx context.Context, document *Document, entry *JournalEntry, journal *Journal, options *DocumentWaitOptions) (*Document, error) {
+	document, err := d.WaitCtx(ctx, document, options)
+	if err != nil && journal != nil && document.Status == DocumentStatusError {
+		journal.finish(entry, err)
 	}
+	return document, err
+}
 
-	return d.DownloadWithOptions(document.ID, downloadOptions)
+// open downloads the translation described by entry. With a journal, the entry
+// is marked completed once the output has been read to the end and closed.
+func (d *DocumentsService) open(ctx context.Context, entry *JournalEntry, journal *Journal) (io.ReadCloser, error) {
+	reader, err := d.DownloadWithOptionsCtx(ctx, entry.ID, &DocumentDownloadOptions{OutputFormat: entry.OutputFormat})
+	if err != nil {
+		return nil, err
+	}
+	if journal != nil {
+		return &journaledReader{ReadCloser: reader, journal: journal, entry: entry}, nil
+	}
+	return reader, nil
 }
 
-func (d *DocumentsService) waitForTranslation(document *Document) (*Document, error) {
-	pollingInterval := 2 * time.Second
-	var maxWaitTime time.Duration
+// Resume finishes the document translations left pending in journal by an
+// earlier process, without uploading the files again. For each of them it
+// waits for the translation and passes the translated output to handle.
+func (d *DocumentsService) Resume(journal *Journal, handle ResumeHandler) error {
+	return d.ResumeWithOptionsCtx(context.Background(), journal, nil, handle)
+}
+
+func (d *DocumentsService) ResumeCtx(ctx context.Context, journal *Journal, handle ResumeHandler) error {
+	return d.ResumeWithOptionsCtx(ctx, journal, nil, handle)
+}
 
-	start := time.Now()
-	current := document
+// ResumeWithOptions is like Resume, but waits for each translation as
+// configured by options and takes the passwords of protected documents from it.
+func (d *DocumentsService) ResumeWithOptions(journal *Journal, options *DocumentResumeOptions, handle ResumeHandler) error {
+	return d.ResumeWithOptionsCtx(context.Background(), journal, options, handle)
+}
 
-	for current.Status != DocumentStatusTranslated && current.Status != DocumentStatusError {
-		if maxWaitTime > 0 && time.Since(start) > maxWaitTime {
-			return current, fmt.Errorf("timeout waiting for translation to complete")
+func (d *DocumentsService) ResumeWithOptionsCtx(ctx context.Context, journal *Journal, options *DocumentResumeOptions, handle ResumeHandler) error {
+	if options == nil {
+		options = &DocumentResumeOptions{}
+	}
+
+	return resumePending(ctx, journal, JournalKindDocument, handle, func(ctx context.Context, entry *JournalEntry) (*resumeResult, error) {
+		var document *Document
+		var err error
+		if entry.ID == "" {
+			var password *string
+			if entry.Protected {
+				if options.Password != nil {
+					password = options.Password(entry)
+				}
+				if password == nil {
+					return nil, fmt.Errorf("%w: %s is password protected; set DocumentResumeOptions.Password to resume it", ErrValidation, entry.Filename)
+				}
+			}
+
+			document, err = d.create(ctx, entry, password)
+			if err != nil {
+				return nil, err
+			}
+			entry.ID = document.ID
+			if err := journal.record(entry); err != nil {
+				return nil, &JournalError{ID: document.ID, Err: err}
+			}
+		} else {
+			document, err = d.StatusCtx(ctx, entry.ID)
+			if errors.Is(err, ErrNotFound) {
+				return &resumeResult{failure: err}, nil
+			}
+			if err != nil {
+				return nil, err
+			}
 		}
 
-		time.Sleep(pollingInterval)
+		document, err = d.WaitCtx(ctx, document, &options.DocumentWaitOptions)
+		if err != nil {
+			if document.Status == DocumentStatusError {
+				return &resumeResult{failure: err}, nil
+			}
+			return nil, err
+		}
 
-		updated, err := d.Status(current.ID)
+		content, err := d.DownloadWithOptionsCtx(ctx, document.ID, &DocumentDownloadOptions{OutputFormat: entry.OutputFormat})
 		if err != nil {
-			return current, err
+			return nil, err
 		}
+		return &resumeResult{content: content}, nil
+	})
+}
+
+// Wait polls the document until it is translated or fails, and returns its
+// last observed state. If options.MaxWaitTime elapses first, the error is a
+// *WaitTimeoutError carrying the document ID, so that the wait can be resumed
+// later with Status and Wait.
+func (d *DocumentsService) Wait(document *Document, options *DocumentWaitOptions) (*Document, error) {
+	return d.WaitCtx(context.Background(), document, options)
+}
+
+func (d *DocumentsService) WaitCtx(ctx context.Context, document *Document, options *DocumentWaitOptions) (*Document, error) {
+	if options == nil {
+		options = &DocumentWaitOptions{}
+	}
+
+	job := d.Job(document)
+	waitOptions := &WaitOptions{
+		PollInterval:    options.PollInterval,
+		BackoffFactor:   options.BackoffFactor,
+		MaxPollInterval: options.MaxPollInterval,
+		MaxWaitTime:     options.MaxWaitTime,
+	}
+	if waitOptions.MaxWaitTime == 0 {
+		waitOptions.MaxWaitTime = defaultDocumentMaxWaitTime
+	} else if waitOptions.MaxWaitTime < 0 {
+		waitOptions.MaxWaitTime = 0
+	}
+	if options.OnProgress != nil {
+		waitOptions.OnProgress = func(Job) { options.OnProgress(job.Document()) }
+	}
+
+	if err := job.Wait(ctx, waitOptions); err != nil && !job.Done() {
+		return job.Document(), fmt.Errorf("failed to wait for translation: %w", err)
+	}
+	return job.Document(), job.Err()
+}
+
+// defaultDocumentMaxWaitTime bounds DocumentsService.Wait when no maximum is set.
+const defaultDocumentMaxWaitTime = 30 * time.Minute
+
+// Job returns a Job tracking the translation of document.
+func (d *DocumentsService) Job(document *Document) *DocumentJob {
+	status, progress, done, err := documentState(document)
+	return &DocumentJob{
+		jobState: newJobState(document.ID, status, progress, done, err),
+		service:  d,
+		document: document,
+	}
+}
+
+// DocumentJob tracks a document translation.
+type DocumentJob struct {
+	*jobState
+	service  *DocumentsService
+	document *Document
+}
+
+// Document returns the last observed state of the document.
+func (j *DocumentJob) Document() *Document {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.document
+}
+
+func (j *DocumentJob) Refresh(ctx context.Context) error {
+	document, err := j.service.StatusCtx(ctx, j.ID())
+	if err != nil {
+		return err
+	}
 
-		current = updated
+	status, progress, done, failure := documentState(document)
+	j.update(status, progress, done, failure, func() { j.document = document })
+	return nil
+}
+
+func (j *DocumentJob) Wait(ctx context.Context, options *WaitOptions) error {
+	return waitJob(ctx, j, j.jobState, options)
+}
+
+func documentState(document *Document) (status string, progress float64, done bool, err error) {
+	progress = -1
+	if document.TranslatedChars != nil && document.TotalChars != nil && *document.TotalChars > 0 {
+		progress = float64(*document.TranslatedChars) / float64(*document.TotalChars)
 	}
 
-	return current, nil
+	switch document.Status {
+	case DocumentStatusTranslated:
+		return string(document.Status), 1, true, nil
+	case DocumentStatusError:
+		errorMsg := "translation failed"
+		if document.ErrorReason != nil {
+			errorMsg = *document.ErrorReason
+		}
+		return string(document.Status), progress, true, fmt.Errorf("document translation failed: %s", errorMsg)
+	}
+	return string(document.Status), progress, false, nil
 }
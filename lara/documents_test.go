@@ -0,0 +1,409 @@
+package lara
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// documentAPI fakes the document endpoints of the Lara API and the S3
+// buckets behind them. Created documents are still translating when first
+// polled and translated afterwards, unless statuses says otherwise; their
+// output is "<id>:<target>".
+type documentAPI struct {
+	*httptest.Server
+	t *testing.T
+
+	mu       sync.Mutex
+	uploads  int
+	created  []map[string]interface{}
+	statuses map[string]DocumentStatus
+	targets  map[string]string
+	// onCreate, if set, runs before a document is created.
+	onCreate func()
+	polls    map[string]int
+	// inFlight and maxInFlight count the created documents that have not
+	// been seen finished yet.
+	inFlight, maxInFlight int
+	finished              map[string]bool
+}
+
+func newDocumentAPI(t *testing.T) *documentAPI {
+	api := &documentAPI{
+		t:        t,
+		statuses: map[string]DocumentStatus{},
+		targets:  map[string]string{},
+		polls:    map[string]int{},
+		finished: map[string]bool{},
+	}
+	api.Server = httptest.NewServer(http.HandlerFunc(api.serveHTTP))
+	t.Cleanup(api.Close)
+	return api
+}
+
+func (api *documentAPI) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	path := r.URL.Path
+	switch {
+	case path == "/v2/auth":
+		fmt.Fprintf(w, `{"token":%q}`, testJWT(time.Now().Add(time.Hour), 1))
+	case path == "/v2/documents/upload-url":
+		api.uploads++
+		fmt.Fprintf(w, `{"url":%q,"fields":{"key":"uploads/%d"}}`, api.URL+"/s3/upload", api.uploads)
+	case path == "/s3/upload":
+		io.Copy(io.Discard, r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	case path == "/v2/documents" && r.Method == "POST":
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			api.t.Errorf("decoding create body: %v", err)
+		}
+		if api.onCreate != nil {
+			api.onCreate()
+		}
+		api.created = append(api.created, body)
+		id := fmt.Sprintf("doc_%d", len(api.created))
+		api.targets[id] = body["target"].(string)
+		api.finished[id] = false
+		api.inFlight++
+		if api.inFlight > api.maxInFlight {
+			api.maxInFlight = api.inFlight
+		}
+		fmt.Fprintf(w, `{"id":%q,"status":"initialized","target":%q}`, id, api.targets[id])
+	case strings.HasPrefix(path, "/v2/documents/") && strings.HasSuffix(path, "/download-url"):
+		id := strings.TrimSuffix(strings.TrimPrefix(path, "/v2/documents/"), "/download-url")
+		fmt.Fprintf(w, `{"url":%q}`, api.URL+"/s3/output/"+id)
+	case strings.HasPrefix(path, "/v2/documents/"):
+		id := strings.TrimPrefix(path, "/v2/documents/")
+		target, ok := api.targets[id]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"type":"NotFound","message":"no such document"}`)
+			return
+		}
+		api.polls[id]++
+		status, ok := api.statuses[id]
+		if !ok {
+			status = DocumentStatusTranslating
+			if api.polls[id] > 1 {
+				status = DocumentStatusTranslated
+			}
+		}
+		if status == DocumentStatusTranslated || status == DocumentStatusError {
+			if finished, created := api.finished[id]; created && !finished {
+				api.finished[id] = true
+				api.inFlight--
+			}
+		}
+		fmt.Fprintf(w, `{"id":%q,"status":%q,"target":%q,"error_reason":"unsupported layout"}`, id, status, target)
+	case strings.HasPrefix(path, "/s3/output/"):
+		id := strings.TrimPrefix(path, "/s3/output/")
+		fmt.Fprintf(w, "%s:%s", id, api.targets[id])
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// addDocument registers a document created by an earlier process.
+func (api *documentAPI) addDocument(id, target string, status DocumentStatus) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	api.targets[id] = target
+	api.statuses[id] = status
+}
+
+func (api *documentAPI) creates() []map[string]interface{} {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	return append([]map[string]interface{}(nil), api.created...)
+}
+
+func (api *documentAPI) counts() (uploads, creates, maxInFlight int) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	return api.uploads, len(api.created), api.maxInFlight
+}
+
+func newTestDocuments(t *testing.T, api *documentAPI) *DocumentsService {
+	translator, err := NewTranslator(NewAccessKey("id", "secret"), &TranslatorOptions{
+		ServerURL:   api.URL,
+		RetryPolicy: &RetryPolicy{MaxAttempts: 1},
+	})
+	if err != nil {
+		t.Fatalf("NewTranslator: %v", err)
+	}
+	return translator.Documents
+}
+
+var fastDocumentWait = DocumentWaitOptions{PollInterval: time.Millisecond}
+
+// breakJournal makes every later record fail, by putting a directory where
+// the journal file was.
+func breakJournal(t *testing.T, journal *Journal) {
+	if err := os.Remove(journal.path); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(journal.path, 0700); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readAndClose(t *testing.T, r io.ReadCloser) string {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("reading output: %v", err)
+	}
+	if err := r.Close(); err != nil {
+		t.Fatalf("closing output: %v", err)
+	}
+	return string(data)
+}
+
+func TestTranslateFromReaderJournal(t *testing.T) {
+	api := newDocumentAPI(t)
+	documents := newTestDocuments(t, api)
+	journal := openTestJournal(t)
+
+	options := &DocumentTranslateOptions{DocumentWaitOptions: fastDocumentWait}
+	options.Journal = journal
+	output, err := documents.TranslateFromReader(context.Background(), NewUploadFile(strings.NewReader("hello"), "a.docx"), nil, "it-IT", options)
+	if err != nil {
+		t.Fatalf("TranslateFromReader: %v", err)
+	}
+	if got := readAndClose(t, output); got != "doc_1:it-IT" {
+		t.Errorf("output %q, want doc_1:it-IT", got)
+	}
+
+	entries, err := journal.Entries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].ID != "doc_1" || entries[0].S3Key != "uploads/1" || entries[0].Status != JournalStatusCompleted {
+		t.Errorf("journal %+v, want doc_1 completed", entries)
+	}
+}
+
+func TestTranslateFromReaderJournalError(t *testing.T) {
+	api := newDocumentAPI(t)
+	documents := newTestDocuments(t, api)
+	journal := openTestJournal(t)
+	api.onCreate = func() { breakJournal(t, journal) }
+
+	options := &DocumentTranslateOptions{DocumentWaitOptions: fastDocumentWait}
+	options.Journal = journal
+	output, err := documents.TranslateFromReader(context.Background(), NewUploadFile(strings.NewReader("hello"), "a.docx"), nil, "it-IT", options)
+	var journalErr *JournalError
+	if !errors.As(err, &journalErr) || journalErr.ID != "doc_1" {
+		t.Fatalf("got %v, %v, want a *JournalError for doc_1", output, err)
+	}
+	if len(api.creates()) != 1 {
+		t.Errorf("the document was created %d times", len(api.creates()))
+	}
+}
+
+func TestTranslateMultiJournalError(t *testing.T) {
+	api := newDocumentAPI(t)
+	documents := newTestDocuments(t, api)
+	journal := openTestJournal(t)
+	api.onCreate = func() {
+		if len(api.created) == 1 {
+			breakJournal(t, journal)
+		}
+	}
+
+	options := &DocumentTranslateOptions{DocumentWaitOptions: fastDocumentWait}
+	options.Journal = journal
+	results, err := documents.TranslateMultiFromReader(context.Background(), NewUploadFile(strings.NewReader("hello"), "a.docx"), nil, []string{"it-IT", "fr-FR"}, options)
+	if err != nil {
+		t.Fatalf("TranslateMultiFromReader: %v", err)
+	}
+
+	journaled := 0
+	for target, result := range results {
+		var journalErr *JournalError
+		if !errors.As(result.Err, &journalErr) {
+			continue
+		}
+		journaled++
+		if result.Document == nil || result.Document.ID != journalErr.ID {
+			t.Errorf("%s: the result does not carry the created document", target)
+		}
+	}
+	if journaled == 0 {
+		t.Error("no result reports the journal error")
+	}
+}
+
+func TestDocumentsResume(t *testing.T) {
+	api := newDocumentAPI(t)
+	documents := newTestDocuments(t, api)
+	journal := openTestJournal(t)
+
+	source := "en-US"
+	entries := []*JournalEntry{
+		// Stopped after the upload: the translation has to be created.
+		{Kind: JournalKindDocument, S3Key: "uploads/a", Filename: "a.docx", Source: &source, Target: "it-IT", Options: map[string]interface{}{"style": "fluid"}, Status: JournalStatusPending},
+		// Stopped while waiting.
+		{Kind: JournalKindDocument, ID: "doc_old", S3Key: "uploads/b", Filename: "b.docx", Target: "fr-FR", Status: JournalStatusPending},
+		{Kind: JournalKindDocument, ID: "doc_failed", S3Key: "uploads/c", Filename: "c.docx", Target: "de-DE", Status: JournalStatusPending},
+		{Kind: JournalKindDocument, ID: "doc_gone", S3Key: "uploads/d", Filename: "d.docx", Target: "de-DE", Status: JournalStatusPending},
+		// Already delivered, or not a document.
+		{Kind: JournalKindDocument, ID: "doc_done", S3Key: "uploads/e", Filename: "e.docx", Target: "es-ES", Status: JournalStatusCompleted},
+		{Kind: JournalKindAudio, S3Key: "uploads/f", Filename: "f.mp3", Target: "es-ES", Status: JournalStatusPending},
+	}
+	for _, entry := range entries {
+		if err := journal.record(entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+	api.addDocument("doc_old", "fr-FR", DocumentStatusTranslating)
+	api.addDocument("doc_failed", "de-DE", DocumentStatusError)
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		api.addDocument("doc_old", "fr-FR", DocumentStatusTranslated)
+	}()
+
+	outcomes := map[string]string{}
+	err := documents.ResumeWithOptionsCtx(context.Background(), journal, &DocumentResumeOptions{DocumentWaitOptions: fastDocumentWait}, func(entry *JournalEntry, content io.ReadCloser, err error) error {
+		if err != nil {
+			outcomes[entry.Filename] = "failed"
+			return nil
+		}
+		outcomes[entry.Filename] = readAndClose(t, content)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Resume: %v", err)
+	}
+
+	want := map[string]string{"a.docx": "doc_1:it-IT", "b.docx": "doc_old:fr-FR", "c.docx": "failed", "d.docx": "failed"}
+	if fmt.Sprint(outcomes) != fmt.Sprint(want) {
+		t.Errorf("outcomes %v, want %v", outcomes, want)
+	}
+
+	created := api.creates()
+	if len(created) != 1 {
+		t.Fatalf("created %d documents, want only the one that was never created", len(created))
+	}
+	if created[0]["s3key"] != "uploads/a" || created[0]["source"] != "en-US" || created[0]["style"] != "fluid" {
+		t.Errorf("create body %v does not match the journaled upload", created[0])
+	}
+
+	pending, err := journal.Pending(JournalKindDocument)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 0 {
+		t.Errorf("%d documents are still pending", len(pending))
+	}
+
+	// Resuming again has nothing left to do.
+	if err := documents.Resume(journal, func(entry *JournalEntry, content io.ReadCloser, err error) error {
+		t.Errorf("%s resumed twice", entry.Filename)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDocumentsResumeProtected(t *testing.T) {
+	api := newDocumentAPI(t)
+	documents := newTestDocuments(t, api)
+	journal := openTestJournal(t)
+
+	options := &DocumentUploadOptions{Password: stringPtr("secret")}
+	entry := newDocumentEntry("uploads/a", "a.pdf", nil, "it-IT", options, "")
+	if err := journal.record(entry); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(journal.path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "secret") {
+		t.Fatal("the password was written to the journal")
+	}
+
+	handle := func(entry *JournalEntry, content io.ReadCloser, err error) error {
+		if content != nil {
+			content.Close()
+		}
+		return err
+	}
+	if err := documents.ResumeWithOptions(journal, &DocumentResumeOptions{DocumentWaitOptions: fastDocumentWait}, handle); !errors.Is(err, ErrValidation) {
+		t.Fatalf("resume without a password: got %v, want ErrValidation", err)
+	}
+	if len(api.creates()) != 0 {
+		t.Fatal("a protected document was created without its password")
+	}
+
+	resumeOptions := &DocumentResumeOptions{
+		DocumentWaitOptions: fastDocumentWait,
+		Password:            func(entry *JournalEntry) *string { return stringPtr("secret") },
+	}
+	if err := documents.ResumeWithOptions(journal, resumeOptions, handle); err != nil {
+		t.Fatalf("resume with a password: %v", err)
+	}
+	if created := api.creates(); len(created) != 1 || created[0]["password"] != "secret" {
+		t.Errorf("create bodies %v, want one with the password", created)
+	}
+}
+
+func TestDocumentsResumeJournalError(t *testing.T) {
+	api := newDocumentAPI(t)
+	documents := newTestDocuments(t, api)
+	journal := openTestJournal(t)
+
+	if err := journal.record(&JournalEntry{Kind: JournalKindDocument, S3Key: "uploads/a", Filename: "a.docx", Target: "it-IT", Status: JournalStatusPending}); err != nil {
+		t.Fatal(err)
+	}
+	api.onCreate = func() { breakJournal(t, journal) }
+
+	err := documents.ResumeWithOptions(journal, &DocumentResumeOptions{DocumentWaitOptions: fastDocumentWait}, func(entry *JournalEntry, content io.ReadCloser, err error) error {
+		t.Error("the handler ran although the new ID was not journaled")
+		return nil
+	})
+	var journalErr *JournalError
+	if !errors.As(err, &journalErr) || journalErr.ID != "doc_1" {
+		t.Fatalf("got %v, want a *JournalError for doc_1", err)
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func TestOutputFormatRejectedBeforeUpload(t *testing.T) {
+	api := newDocumentAPI(t)
+	documents := newTestDocuments(t, api)
+
+	options := &DocumentTranslateOptions{DocumentWaitOptions: fastDocumentWait}
+	options.OutputFormat = "docs"
+	if _, err := documents.TranslateFromReader(context.Background(), NewUploadFile(strings.NewReader("hello"), "a.docx"), nil, "it-IT", options); !errors.Is(err, ErrValidation) {
+		t.Errorf("TranslateFromReader: got %v, want ErrValidation", err)
+	}
+	if _, err := documents.TranslateMultiFromReader(context.Background(), NewUploadFile(strings.NewReader("hello"), "a.docx"), nil, []string{"it-IT"}, options); !errors.Is(err, ErrValidation) {
+		t.Errorf("TranslateMultiFromReader: got %v, want ErrValidation", err)
+	}
+	if uploads, _, _ := api.counts(); uploads != 0 {
+		t.Errorf("%d uploads before rejecting the format", uploads)
+	}
+
+	options.OutputFormat = OutputFormatPdf
+	output, err := documents.TranslateFromReader(context.Background(), NewUploadFile(strings.NewReader("hello"), "a.docx"), nil, "it-IT", options)
+	if err != nil {
+		t.Fatalf("TranslateFromReader: %v", err)
+	}
+	readAndClose(t, output)
+}
@@ -0,0 +1,351 @@
+package lara
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// DownloadProgressFunc is called as a download is written to disk. total is -1
+// when the server did not announce the size.
+type DownloadProgressFunc func(written, total int64)
+
+// DownloadedFile describes a file written by DocumentsService.DownloadToFile
+// or AudioTranslator.DownloadToFile.
+type DownloadedFile struct {
+	// Path is where the file was written.
+	Path string
+	// Filename is the name suggested by the server, from Content-Disposition
+	// or the object key.
+	Filename string
+	// ContentType is the MIME type reported by the server or inferred from Filename.
+	ContentType string
+	// Size is the number of bytes written.
+	Size int64
+}
+
+// downloadToFile downloads url to path. The content is written to a hidden
+// .part file in the same directory and renamed into place once its length
+// has been checked against the announced size, so path never holds a partial
+// file. An interrupted transfer is resumed with a Range request, both on the
+// next attempt of the retry policy and on a later call for the same path:
+// when the server identifies the content with an ETag or Last-Modified date,
+// the .part file is kept and the resumed request carries it in If-Range, so
+// that a changed object is downloaded again from the start. Concurrent
+// downloads to the same path are not supported.
+//
+// If path is an existing directory, or ends with a path separator, the file
+// is saved there under the name suggested by the server. A non-empty
+// extension replaces the one of that name.
+func (s *S3Client) downloadToFile(ctx context.Context, url, path, extension string, onProgress DownloadProgressFunc) (*DownloadedFile, error) {
+	dl := &fileDownload{target: path, extension: extension, total: -1, onProgress: onProgress}
+	dl.load(url)
+	defer dl.close()
+
+	err := s.retryPolicy.run(ctx, true, func() error {
+		return s.downloadAttempt(ctx, url, dl)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dl.commit()
+}
+
+// fileDownload is the state of a download kept across retry attempts and,
+// through its .part file, across calls.
+type fileDownload struct {
+	target     string
+	extension  string
+	onProgress DownloadProgressFunc
+
+	partPath  string
+	file      *os.File
+	written   int64
+	total     int64
+	validator string
+	result    DownloadedFile
+}
+
+// downloadState is saved next to the .part file so that a later call can
+// resume the download.
+type downloadState struct {
+	Validator   string `json:"validator"`
+	Total       int64  `json:"total"`
+	Path        string `json:"path"`
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type"`
+}
+
+// load sets the .part file of the download and picks up what an earlier call
+// left in it, if anything. For a directory target, whose final name is only
+// known from the response, the .part file is named after the URL.
+func (dl *fileDownload) load(rawURL string) {
+	if isDirectoryTarget(dl.target) {
+		dl.partPath = filepath.Join(dl.target, "."+suggestedFilename(rawURL, "")+".part")
+	} else {
+		dl.partPath = filepath.Join(filepath.Dir(dl.target), "."+filepath.Base(dl.target)+".part")
+	}
+
+	data, err := os.ReadFile(dl.statePath())
+	if err != nil {
+		return
+	}
+	var state downloadState
+	if json.Unmarshal(data, &state) != nil || state.Validator == "" || state.Path == "" {
+		return
+	}
+
+	file, err := os.OpenFile(dl.partPath, os.O_RDWR, 0644)
+	if err != nil {
+		return
+	}
+	size, err := file.Seek(0, io.SeekEnd)
+	if err != nil || (state.Total >= 0 && size > state.Total) {
+		file.Close()
+		return
+	}
+
+	dl.file = file
+	dl.written = size
+	dl.total = state.Total
+	dl.validator = state.Validator
+	dl.result = DownloadedFile{Path: state.Path, Filename: state.Filename, ContentType: state.ContentType}
+}
+
+func (dl *fileDownload) statePath() string {
+	return dl.partPath + ".json"
+}
+
+func (s *S3Client) downloadAttempt(ctx context.Context, rawURL string, dl *fileDownload) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", rawURL, nil)
+	if err != nil {
+		return err
+	}
+	if dl.written > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", dl.written))
+		if dl.validator != "" {
+			req.Header.Set("If-Range", dl.validator)
+		}
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return transportError(ctx, err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusPartialContent && dl.written > 0:
+		start, total, ok := parseContentRange(resp.Header.Get("Content-Range"))
+		if !ok || start != dl.written {
+			return &finalError{fmt.Errorf("unexpected Content-Range %q when resuming at byte %d", resp.Header.Get("Content-Range"), dl.written)}
+		}
+		dl.total = total
+	case resp.StatusCode == http.StatusRequestedRangeNotSatisfiable && dl.written > 0 && dl.written == dl.total:
+		return nil
+	case resp.StatusCode >= 400:
+		return newS3Error("download", resp)
+	default:
+		// A full response, either the first one or because the object changed
+		// and the server ignored the range.
+		if err := dl.start(rawURL, resp); err != nil {
+			return &finalError{err}
+		}
+	}
+
+	return dl.copy(ctx, resp.Body)
+}
+
+// start prepares the .part file for a full response.
+func (dl *fileDownload) start(rawURL string, resp *http.Response) error {
+	dl.total = resp.ContentLength
+	dl.validator = resp.Header.Get("ETag")
+	if dl.validator == "" || strings.HasPrefix(dl.validator, "W/") {
+		// Weak ETags cannot be used in If-Range.
+		dl.validator = resp.Header.Get("Last-Modified")
+	}
+	dl.result.Filename = suggestedFilename(rawURL, resp.Header.Get("Content-Disposition"))
+	if dl.extension != "" && !strings.EqualFold(filepath.Ext(dl.result.Filename), dl.extension) {
+		dl.result.Filename = strings.TrimSuffix(dl.result.Filename, filepath.Ext(dl.result.Filename)) + dl.extension
+	}
+	dl.result.ContentType = resp.Header.Get("Content-Type")
+	if dl.result.ContentType == "" || dl.result.ContentType == "application/octet-stream" || dl.result.ContentType == "binary/octet-stream" {
+		if byExt := mime.TypeByExtension(filepath.Ext(dl.result.Filename)); byExt != "" {
+			dl.result.ContentType = byExt
+		}
+	}
+	dl.result.Path = dl.target
+	if isDirectoryTarget(dl.target) {
+		dl.result.Path = filepath.Join(dl.target, dl.result.Filename)
+	}
+
+	dl.written = 0
+	if dl.file != nil {
+		if err := dl.file.Truncate(0); err != nil {
+			return err
+		}
+		if _, err := dl.file.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+	} else {
+		if err := os.MkdirAll(filepath.Dir(dl.partPath), 0755); err != nil {
+			return fmt.Errorf("failed to create download directory: %w", err)
+		}
+		file, err := os.OpenFile(dl.partPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+		if err != nil {
+			return fmt.Errorf("failed to create download file: %w", err)
+		}
+		dl.file = file
+	}
+
+	if dl.validator == "" {
+		os.Remove(dl.statePath())
+		return nil
+	}
+	state, err := json.Marshal(downloadState{
+		Validator:   dl.validator,
+		Total:       dl.total,
+		Path:        dl.result.Path,
+		Filename:    dl.result.Filename,
+		ContentType: dl.result.ContentType,
+	})
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(dl.statePath(), bytes.NewReader(state)); err != nil {
+		return fmt.Errorf("failed to save download state: %w", err)
+	}
+	return nil
+}
+
+// copy appends body to the .part file. A body that ends early is reported as
+// a connection error, so that the retry policy resumes it.
+func (dl *fileDownload) copy(ctx context.Context, body io.Reader) error {
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := body.Read(buf)
+		if n > 0 {
+			if _, werr := dl.file.Write(buf[:n]); werr != nil {
+				return &finalError{fmt.Errorf("failed to write download file: %w", werr)}
+			}
+			dl.written += int64(n)
+			if dl.onProgress != nil {
+				dl.onProgress(dl.written, dl.total)
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return transportError(ctx, err)
+		}
+	}
+
+	if dl.total >= 0 && dl.written < dl.total {
+		return &LaraConnectionError{Message: fmt.Sprintf("download ended after %d of %d bytes", dl.written, dl.total), Err: io.ErrUnexpectedEOF}
+	}
+	if dl.total >= 0 && dl.written > dl.total {
+		return &finalError{fmt.Errorf("download returned %d bytes, %d expected", dl.written, dl.total)}
+	}
+	return nil
+}
+
+// commit moves the completed .part file into place.
+func (dl *fileDownload) commit() (*DownloadedFile, error) {
+	if dl.file == nil {
+		return nil, errors.New("download returned no content")
+	}
+
+	err := replaceFile(dl.file, dl.result.Path)
+	dl.file = nil
+	os.Remove(dl.statePath())
+	if err != nil {
+		return nil, fmt.Errorf("failed to move download into place: %w", err)
+	}
+
+	dl.result.Size = dl.written
+	result := dl.result
+	return &result, nil
+}
+
+// close releases the .part file of a download that did not complete. It is
+// kept for a later call to resume, unless there is nothing to resume or the
+// content cannot be validated.
+func (dl *fileDownload) close() {
+	if dl.file == nil {
+		return
+	}
+	dl.file.Close()
+	if dl.written == 0 || dl.validator == "" {
+		os.Remove(dl.partPath)
+		os.Remove(dl.statePath())
+	}
+}
+
+// isDirectoryTarget reports whether a download to target is saved inside it.
+func isDirectoryTarget(target string) bool {
+	if strings.HasSuffix(target, string(os.PathSeparator)) || strings.HasSuffix(target, "/") {
+		return true
+	}
+	info, err := os.Stat(target)
+	return err == nil && info.IsDir()
+}
+
+// parseContentRange parses "bytes start-end/total"; total is -1 when it is "*".
+func parseContentRange(value string) (start, total int64, ok bool) {
+	value = strings.TrimSpace(value)
+	if !strings.HasPrefix(value, "bytes ") {
+		return 0, 0, false
+	}
+	parts := strings.SplitN(strings.TrimPrefix(value, "bytes "), "/", 2)
+	if len(parts) != 2 {
+		return 0, 0, false
+	}
+	span := strings.SplitN(parts[0], "-", 2)
+	if len(span) != 2 {
+		return 0, 0, false
+	}
+
+	start, err := strconv.ParseInt(span[0], 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	total = -1
+	if parts[1] != "*" {
+		if total, err = strconv.ParseInt(parts[1], 10, 64); err != nil {
+			return 0, 0, false
+		}
+	}
+	return start, total, true
+}
+
+// suggestedFilename takes the filename from Content-Disposition, falling back
+// to the last segment of the URL path.
+func suggestedFilename(rawURL, contentDisposition string) string {
+	if contentDisposition != "" {
+		if _, params, err := mime.ParseMediaType(contentDisposition); err == nil {
+			if name := filepath.Base(params["filename"]); name != "" && name != "." && name != string(os.PathSeparator) {
+				return name
+			}
+		}
+	}
+
+	if u, err := url.Parse(rawURL); err == nil {
+		if name := path.Base(u.Path); name != "" && name != "." && name != "/" {
+			return name
+		}
+	}
+	return "download"
+}
@@ -0,0 +1,281 @@
+package lara
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// objectServer serves one object the way S3 does, honoring Range and
+// If-Range.
+type objectServer struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	content []byte
+	etag    string
+	// cut, if set, ends the next response after that many bytes of its body.
+	cut int
+	// contentRange, if set, replaces the Content-Range of partial responses.
+	contentRange string
+	requests     []http.Header
+}
+
+func newObjectServer(t *testing.T, content []byte, etag string) *objectServer {
+	o := &objectServer{content: content, etag: etag}
+	o.Server = httptest.NewServer(http.HandlerFunc(o.serveHTTP))
+	t.Cleanup(o.Close)
+	return o
+}
+
+func (o *objectServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.requests = append(o.requests, r.Header.Clone())
+
+	start := 0
+	if r.Header.Get("Range") != "" && (r.Header.Get("If-Range") == "" || r.Header.Get("If-Range") == o.etag) {
+		fmt.Sscanf(r.Header.Get("Range"), "bytes=%d-", &start)
+	}
+
+	if o.etag != "" {
+		w.Header().Set("ETag", o.etag)
+	}
+	w.Header().Set("Content-Disposition", `attachment; filename="report.docx"`)
+	body := o.content[start:]
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	if start > 0 {
+		contentRange := o.contentRange
+		if contentRange == "" {
+			contentRange = fmt.Sprintf("bytes %d-%d/%d", start, len(o.content)-1, len(o.content))
+		}
+		w.Header().Set("Content-Range", contentRange)
+		w.WriteHeader(http.StatusPartialContent)
+	}
+
+	if o.cut > 0 && o.cut < len(body) {
+		body = body[:o.cut]
+		o.cut = 0
+	}
+	w.Write(body)
+}
+
+func (o *objectServer) set(content []byte, etag string, cut int) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.content, o.etag, o.cut = content, etag, cut
+}
+
+// headers returns the Range and If-Range headers of every request so far.
+func (o *objectServer) headers() []string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	var result []string
+	for _, h := range o.requests {
+		result = append(result, h.Get("Range")+" "+h.Get("If-Range"))
+	}
+	return result
+}
+
+func testContent(n int, seed byte) []byte {
+	content := make([]byte, n)
+	for i := range content {
+		content[i] = seed + byte(i%200)
+	}
+	return content
+}
+
+func newTestS3Client(attempts int) *S3Client {
+	return newS3Client(http.DefaultClient, &RetryPolicy{
+		MaxAttempts:           attempts,
+		BaseDelay:             time.Millisecond,
+		RetryConnectionErrors: true,
+	})
+}
+
+// assertDownloaded checks that path holds content and no download state is left.
+func assertDownloaded(t *testing.T, path string, content []byte) {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, content) {
+		t.Errorf("%s holds %d bytes that differ from the %d expected", path, len(data), len(content))
+	}
+	leftovers, _ := filepath.Glob(filepath.Join(filepath.Dir(path), ".*"))
+	if len(leftovers) > 0 {
+		t.Errorf("download state left behind: %v", leftovers)
+	}
+}
+
+func TestDownloadResumesWithinCall(t *testing.T) {
+	content := testContent(100000, 0)
+	server := newObjectServer(t, content, `"v1"`)
+	server.cut = 40000
+	path := filepath.Join(t.TempDir(), "out.docx")
+
+	var progress []int64
+	file, err := newTestS3Client(3).downloadToFile(context.Background(), server.URL+"/file", path, "", func(written, total int64) {
+		if total != int64(len(content)) {
+			t.Errorf("progress total %d, want %d", total, len(content))
+		}
+		progress = append(progress, written)
+	})
+	if err != nil {
+		t.Fatalf("downloadToFile: %v", err)
+	}
+	assertDownloaded(t, path, content)
+	if file.Path != path || file.Filename != "report.docx" || file.Size != int64(len(content)) {
+		t.Errorf("got %+v", file)
+	}
+	if progress[len(progress)-1] != int64(len(content)) {
+		t.Errorf("progress ended at %d", progress[len(progress)-1])
+	}
+
+	want := []string{" ", `bytes=40000- "v1"`}
+	if got := server.headers(); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("requests %q, want %q", got, want)
+	}
+}
+
+func TestDownloadResumesAcrossCalls(t *testing.T) {
+	content := testContent(100000, 0)
+	server := newObjectServer(t, content, `"v1"`)
+	server.cut = 40000
+	dir := t.TempDir()
+	path := filepath.Join(dir, "out.docx")
+	partPath := filepath.Join(dir, ".out.docx.part")
+
+	_, err := newTestS3Client(1).downloadToFile(context.Background(), server.URL+"/file", path, "", nil)
+	if !errors.Is(err, ErrConnection) {
+		t.Fatalf("got %v, want a connection error", err)
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("a partial file was written to %s", path)
+	}
+	if info, err := os.Stat(partPath); err != nil || info.Size() != 40000 {
+		t.Fatalf("the .part file was not kept: %v", err)
+	}
+	data, err := os.ReadFile(partPath + ".json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var state downloadState
+	if err := json.Unmarshal(data, &state); err != nil {
+		t.Fatal(err)
+	}
+	if state.Validator != `"v1"` || state.Total != int64(len(content)) || state.Path != path || state.Filename != "report.docx" {
+		t.Errorf("download state %+v", state)
+	}
+
+	if _, err := newTestS3Client(1).downloadToFile(context.Background(), server.URL+"/file", path, "", nil); err != nil {
+		t.Fatalf("downloadToFile: %v", err)
+	}
+	assertDownloaded(t, path, content)
+	if got := server.headers(); got[len(got)-1] != `bytes=40000- "v1"` {
+		t.Errorf("the second call sent %q", got[len(got)-1])
+	}
+}
+
+func TestDownloadRestartsWhenChanged(t *testing.T) {
+	server := newObjectServer(t, testContent(100000, 0), `"v1"`)
+	server.cut = 40000
+	path := filepath.Join(t.TempDir(), "out.docx")
+
+	if _, err := newTestS3Client(1).downloadToFile(context.Background(), server.URL+"/file", path, "", nil); err == nil {
+		t.Fatal("the interrupted download succeeded")
+	}
+
+	changed := testContent(70000, 7)
+	server.set(changed, `"v2"`, 0)
+	if _, err := newTestS3Client(1).downloadToFile(context.Background(), server.URL+"/file", path, "", nil); err != nil {
+		t.Fatalf("downloadToFile: %v", err)
+	}
+	assertDownloaded(t, path, changed)
+	if got := server.headers(); got[len(got)-1] != `bytes=40000- "v1"` {
+		t.Errorf("the second call sent %q", got[len(got)-1])
+	}
+}
+
+func TestDownloadWithoutValidator(t *testing.T) {
+	content := testContent(100000, 0)
+	server := newObjectServer(t, content, "")
+	server.cut = 40000
+	dir := t.TempDir()
+	path := filepath.Join(dir, "out.docx")
+
+	// Within a call the range is still requested, without If-Range.
+	if _, err := newTestS3Client(2).downloadToFile(context.Background(), server.URL+"/file", path, "", nil); err != nil {
+		t.Fatalf("downloadToFile: %v", err)
+	}
+	assertDownloaded(t, path, content)
+	if got := server.headers(); got[1] != "bytes=40000- " {
+		t.Errorf("the retry sent %q", got[1])
+	}
+
+	// Across calls nothing is kept, since a changed object could not be told apart.
+	server.set(content, "", 40000)
+	if _, err := newTestS3Client(1).downloadToFile(context.Background(), server.URL+"/file", filepath.Join(dir, "again.docx"), "", nil); err == nil {
+		t.Fatal("the interrupted download succeeded")
+	}
+	if leftovers, _ := filepath.Glob(filepath.Join(dir, ".*")); len(leftovers) > 0 {
+		t.Errorf("download state kept without a validator: %v", leftovers)
+	}
+}
+
+func TestDownloadChecksLength(t *testing.T) {
+	tests := []struct {
+		name         string
+		contentRange string
+	}{
+		{"wrong start", "bytes 30000-99999/100000"},
+		{"more than announced", "bytes 40000-59999/60000"},
+		{"malformed", "40000-99999"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newObjectServer(t, testContent(100000, 0), `"v1"`)
+			server.cut = 40000
+			server.contentRange = tt.contentRange
+			path := filepath.Join(t.TempDir(), "out.docx")
+
+			_, err := newTestS3Client(3).downloadToFile(context.Background(), server.URL+"/file", path, "", nil)
+			if err == nil || errors.Is(err, ErrConnection) {
+				t.Fatalf("got %v, want a final error", err)
+			}
+			if got := server.headers(); len(got) != 2 {
+				t.Errorf("%d requests, want the resume not to be retried", len(got))
+			}
+			if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+				t.Errorf("a bad download was moved into place")
+			}
+		})
+	}
+}
+
+func TestDownloadToDirectory(t *testing.T) {
+	content := testContent(1000, 0)
+	server := newObjectServer(t, content, `"v1"`)
+	dir := t.TempDir()
+
+	file, err := newTestS3Client(1).downloadToFile(context.Background(), server.URL+"/file", dir+string(os.PathSeparator), ".pdf", nil)
+	if err != nil {
+		t.Fatalf("downloadToFile: %v", err)
+	}
+	if file.Filename != "report.pdf" || file.Path != filepath.Join(dir, "report.pdf") || file.ContentType != "application/pdf" {
+		t.Errorf("got %+v", file)
+	}
+	assertDownloaded(t, file.Path, content)
+}
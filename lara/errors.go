@@ -1,29 +1,156 @@
 package lara
 
-import "fmt"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
 
+// Sentinel errors for the common failure classes. Every error returned by the
+// SDK can be tested against them with errors.Is, whatever wrapping it has
+// gone through; use errors.As with *LaraError, *S3Error, *LaraTimeoutError or
+// *LaraConnectionError to get at the details.
+var (
+	ErrAuthentication = errors.New("lara: authentication failed")
+	ErrPermission     = errors.New("lara: permission denied")
+	ErrNotFound       = errors.New("lara: not found")
+	ErrRateLimited    = errors.New("lara: rate limited")
+	ErrQuotaExceeded  = errors.New("lara: quota exceeded")
+	ErrValidation     = errors.New("lara: invalid request")
+	ErrServer         = errors.New("lara: server error")
+	ErrTimeout        = errors.New("lara: timeout")
+	ErrConnection     = errors.New("lara: connection error")
+)
+
+// LaraError is an error response returned by the Lara API.
 type LaraError struct {
 	Status  int
 	Type    string
 	Message string
+	// RequestID identifies the request in the server logs, when the server sent one.
+	RequestID string
+	// RetryAfter is the delay requested by the server's Retry-After header, if any.
+	RetryAfter time.Duration
 }
 
 func (e *LaraError) Error() string {
 	return fmt.Sprintf("%s: %s", e.Type, e.Message)
 }
 
+// Is reports whether the error belongs to the class of the given sentinel, e.g. ErrNotFound.
+func (e *LaraError) Is(target error) bool {
+	return target != nil && target == classifyStatus(e.Status, e.Type)
+}
+
 type LaraConnectionError struct {
 	Message string
+	Err     error
 }
 
 func (e *LaraConnectionError) Error() string {
 	return fmt.Sprintf("ConnectionError: %s", e.Message)
 }
 
+func (e *LaraConnectionError) Unwrap() error {
+	return e.Err
+}
+
+func (e *LaraConnectionError) Is(target error) bool {
+	return target == ErrConnection
+}
+
 type LaraTimeoutError struct {
 	Message string
+	Err     error
 }
 
 func (e *LaraTimeoutError) Error() string {
 	return fmt.Sprintf("TimeoutError: %s", e.Message)
 }
+
+func (e *LaraTimeoutError) Unwrap() error {
+	return e.Err
+}
+
+func (e *LaraTimeoutError) Is(target error) bool {
+	return target == ErrTimeout
+}
+
+// WaitTimeoutError is returned when a long-running operation, such as a
+// document or audio translation, does not finish within the maximum wait time.
+// The operation keeps running on the server and can be waited for again by ID.
+type WaitTimeoutError struct {
+	ID     string
+	Status string
+	Waited time.Duration
+}
+
+func (e *WaitTimeoutError) Error() string {
+	return fmt.Sprintf("timed out after %s waiting for %s (last status: %s)", e.Waited.Round(time.Millisecond), e.ID, e.Status)
+}
+
+func (e *WaitTimeoutError) Is(target error) bool {
+	return target == ErrTimeout
+}
+
+// S3Error is returned when a file transfer to or from S3 fails with an HTTP error status.
+type S3Error struct {
+	Operation  string
+	Status     int
+	RequestID  string
+	RetryAfter time.Duration
+}
+
+func (e *S3Error) Error() string {
+	return fmt.Sprintf("%s failed with status %d", e.Operation, e.Status)
+}
+
+// Is reports whether the error belongs to the class of the given sentinel, e.g. ErrPermission
+// for an expired presigned URL.
+func (e *S3Error) Is(target error) bool {
+	return target != nil && target == classifyStatus(e.Status, "")
+}
+
+// classifyStatus maps an HTTP status and API error type to a sentinel error.
+func classifyStatus(status int, errorType string) error {
+	if strings.Contains(strings.ToLower(errorType), "quota") {
+		return ErrQuotaExceeded
+	}
+
+	switch {
+	case status == 401:
+		return ErrAuthentication
+	case status == 402:
+		return ErrQuotaExceeded
+	case status == 403:
+		return ErrPermission
+	case status == 404:
+		return ErrNotFound
+	case status == 408:
+		return ErrTimeout
+	case status == 429:
+		return ErrRateLimited
+	case status >= 500:
+		return ErrServer
+	case status >= 400:
+		return ErrValidation
+	}
+	return nil
+}
+
+// transportError converts an error returned by http.Client.Do into the SDK's
+// connection and timeout errors, leaving context cancellation untouched.
+func transportError(ctx context.Context, err error) error {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return &LaraTimeoutError{Message: err.Error(), Err: err}
+	}
+	return &LaraConnectionError{Message: err.Error(), Err: err}
+}
@@ -0,0 +1,96 @@
+package lara
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+)
+
+func TestClassifyStatus(t *testing.T) {
+	tests := []struct {
+		status    int
+		errorType string
+		want      error
+	}{
+		{200, "", nil},
+		{302, "", nil},
+		{400, "InvalidRequest", ErrValidation},
+		{401, "AuthenticationError", ErrAuthentication},
+		{402, "", ErrQuotaExceeded},
+		{403, "", ErrPermission},
+		{404, "", ErrNotFound},
+		{408, "", ErrTimeout},
+		{409, "", ErrValidation},
+		{422, "", ErrValidation},
+		{429, "", ErrRateLimited},
+		{429, "QuotaExceededError", ErrQuotaExceeded},
+		{403, "monthly_quota_exceeded", ErrQuotaExceeded},
+		{500, "", ErrServer},
+		{503, "", ErrServer},
+	}
+
+	for _, tt := range tests {
+		if got := classifyStatus(tt.status, tt.errorType); got != tt.want {
+			t.Errorf("classifyStatus(%d, %q) = %v, want %v", tt.status, tt.errorType, got, tt.want)
+		}
+	}
+}
+
+func TestErrorsIsAs(t *testing.T) {
+	apiErr := &LaraError{Status: 404, Type: "NotFound", Message: "no such document", RequestID: "req_1"}
+	wrapped := fmt.Errorf("failed to get document: %w", fmt.Errorf("status: %w", apiErr))
+
+	if !errors.Is(wrapped, ErrNotFound) {
+		t.Error("wrapped 404 does not match ErrNotFound")
+	}
+	if errors.Is(wrapped, ErrServer) || errors.Is(wrapped, ErrValidation) {
+		t.Error("wrapped 404 matches another sentinel")
+	}
+	var got *LaraError
+	if !errors.As(wrapped, &got) || got != apiErr {
+		t.Fatal("errors.As does not find the wrapped *LaraError")
+	}
+	if got.RequestID != "req_1" {
+		t.Errorf("RequestID %q, want req_1", got.RequestID)
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"s3 expired url", &S3Error{Operation: "download", Status: 403}, ErrPermission},
+		{"s3 server error", &S3Error{Operation: "upload", Status: 500}, ErrServer},
+		{"connection", &LaraConnectionError{Message: "reset"}, ErrConnection},
+		{"timeout", &LaraTimeoutError{Message: "slow"}, ErrTimeout},
+		{"wait timeout", &WaitTimeoutError{ID: "doc_1"}, ErrTimeout},
+		{"quota type", &LaraError{Status: 429, Type: "QuotaExceeded"}, ErrQuotaExceeded},
+	}
+	for _, tt := range tests {
+		if err := fmt.Errorf("wrapped: %w", tt.err); !errors.Is(err, tt.want) {
+			t.Errorf("%s: %v does not match %v", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestTransportError(t *testing.T) {
+	timeout := &net.DNSError{Err: "timed out", IsTimeout: true}
+	if err := transportError(context.Background(), timeout); !errors.Is(err, ErrTimeout) {
+		t.Errorf("net timeout gave %v, want ErrTimeout", err)
+	}
+
+	refused := &net.OpError{Op: "dial", Err: errors.New("connection refused")}
+	err := transportError(context.Background(), refused)
+	var connErr *LaraConnectionError
+	if !errors.Is(err, ErrConnection) || !errors.As(err, &connErr) || !errors.Is(err, refused) {
+		t.Errorf("dial error gave %v, want a *LaraConnectionError wrapping it", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := transportError(ctx, refused); err != context.Canceled {
+		t.Errorf("cancelled context gave %v, want context.Canceled", err)
+	}
+}
@@ -0,0 +1,47 @@
+package lara
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+)
+
+// lockPollInterval is how often a contended file lock is retried.
+const lockPollInterval = 50 * time.Millisecond
+
+// lockFile takes an exclusive lock on file. The platform lock is tried without
+// blocking and polled, so that waiting for another process honors ctx.
+func lockFile(ctx context.Context, file *os.File) error {
+	for {
+		locked, err := tryLockFile(file)
+		if err != nil {
+			return fmt.Errorf("failed to lock %s: %w", file.Name(), err)
+		}
+		if locked {
+			return nil
+		}
+		if err := sleepContext(ctx, lockPollInterval); err != nil {
+			return err
+		}
+	}
+}
+
+// lockPath takes an exclusive lock on the file at path, creating it if needed,
+// and returns the function that releases it.
+func lockPath(ctx context.Context, path string) (func(), error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lock file: %w", err)
+	}
+
+	if err := lockFile(ctx, file); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return func() {
+		unlockFile(file)
+		file.Close()
+	}, nil
+}
@@ -0,0 +1,61 @@
+package lara
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// DocumentOutputFormat is the format a translated document is downloaded in.
+// The zero value keeps the format of the original document.
+type DocumentOutputFormat string
+
+// Output formats for DocumentDownloadOptions.OutputFormat. Which conversions
+// are available depends on the input document and is checked by the API.
+const (
+	OutputFormatOriginal DocumentOutputFormat = ""
+	OutputFormatDocx     DocumentOutputFormat = "docx"
+	OutputFormatPptx     DocumentOutputFormat = "pptx"
+	OutputFormatXlsx     DocumentOutputFormat = "xlsx"
+	OutputFormatPdf      DocumentOutputFormat = "pdf"
+	OutputFormatHtml     DocumentOutputFormat = "html"
+	OutputFormatTxt      DocumentOutputFormat = "txt"
+)
+
+var documentOutputFormats = map[DocumentOutputFormat]bool{
+	OutputFormatOriginal: true,
+	OutputFormatDocx:     true,
+	OutputFormatPptx:     true,
+	OutputFormatXlsx:     true,
+	OutputFormatPdf:      true,
+	OutputFormatHtml:     true,
+	OutputFormatTxt:      true,
+}
+
+// validateOutputFormat rejects a format that is not one of the OutputFormat
+// constants, so that it fails before the document is uploaded.
+func validateOutputFormat(format DocumentOutputFormat) error {
+	if !documentOutputFormats[format] {
+		return fmt.Errorf("%w: unsupported document output format %q", ErrValidation, format)
+	}
+	return nil
+}
+
+// Extension returns the file extension of the format, including the dot, or
+// "" for OutputFormatOriginal.
+func (f DocumentOutputFormat) Extension() string {
+	if f == OutputFormatOriginal {
+		return ""
+	}
+	return "." + string(f)
+}
+
+// OutputFilename returns the name of the translated document: filename with its
+// extension replaced by the one of format, or unchanged for OutputFormatOriginal.
+func OutputFilename(filename string, format DocumentOutputFormat) string {
+	ext := format.Extension()
+	if ext == "" || strings.EqualFold(filepath.Ext(filename), ext) {
+		return filename
+	}
+	return strings.TrimSuffix(filename, filepath.Ext(filename)) + ext
+}
@@ -1,8 +1,11 @@
 package lara
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 )
@@ -20,8 +23,12 @@ func newGlossariesService(client *Client) *GlossariesService {
 }
 
 func (g *GlossariesService) List() ([]Glossary, error) {
+	return g.ListCtx(context.Background())
+}
+
+func (g *GlossariesService) ListCtx(ctx context.Context) ([]Glossary, error) {
 	var glossaries []Glossary
-	err := g.client.Get("/v2/glossaries", nil, nil, &glossaries)
+	err := g.client.Get(ctx, "/v2/glossaries", nil, nil, &glossaries)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list glossaries: %w", err)
 	}
@@ -29,12 +36,16 @@ func (g *GlossariesService) List() ([]Glossary, error) {
 }
 
 func (g *GlossariesService) Create(name string) (*Glossary, error) {
+	return g.CreateCtx(context.Background(), name)
+}
+
+func (g *GlossariesService) CreateCtx(ctx context.Context, name string) (*Glossary, error) {
 	body := map[string]interface{}{
 		"name": name,
 	}
 
 	var glossary Glossary
-	err := g.client.Post("/v2/glossaries", body, nil, nil, &glossary)
+	err := g.client.Post(ctx, "/v2/glossaries", body, nil, nil, &glossary)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create glossary: %w", err)
 	}
@@ -42,10 +53,14 @@ func (g *GlossariesService) Create(name string) (*Glossary, error) {
 }
 
 func (g *GlossariesService) Get(id string) (*Glossary, error) {
+	return g.GetCtx(context.Background(), id)
+}
+
+func (g *GlossariesService) GetCtx(ctx context.Context, id string) (*Glossary, error) {
 	var glossary Glossary
-	err := g.client.Get(fmt.Sprintf("/v2/glossaries/%s", id), nil, nil, &glossary)
+	err := g.client.Get(ctx, fmt.Sprintf("/v2/glossaries/%s", id), nil, nil, &glossary)
 	if err != nil {
-		if laraErr, ok := err.(*LaraError); ok && laraErr.Status == 404 {
+		if errors.Is(err, ErrNotFound) {
 			return nil, nil
 		}
 		return nil, fmt.Errorf("failed to get glossary: %w", err)
@@ -54,8 +69,12 @@ func (g *GlossariesService) Get(id string) (*Glossary, error) {
 }
 
 func (g *GlossariesService) Delete(id string) (*Glossary, error) {
+	return g.DeleteCtx(context.Background(), id)
+}
+
+func (g *GlossariesService) DeleteCtx(ctx context.Context, id string) (*Glossary, error) {
 	var glossary Glossary
-	err := g.client.Delete(fmt.Sprintf("/v2/glossaries/%s", id), nil, nil, &glossary)
+	err := g.client.Delete(ctx, fmt.Sprintf("/v2/glossaries/%s", id), nil, nil, &glossary)
 	if err != nil {
 		return nil, fmt.Errorf("failed to delete glossary: %w", err)
 	}
@@ -63,12 +82,16 @@ func (g *GlossariesService) Delete(id string) (*Glossary, error) {
 }
 
 func (g *GlossariesService) Update(id, name string) (*Glossary, error) {
+	return g.UpdateCtx(context.Background(), id, name)
+}
+
+func (g *GlossariesService) UpdateCtx(ctx context.Context, id, name string) (*Glossary, error) {
 	body := map[string]interface{}{
 		"name": name,
 	}
 
 	var glossary Glossary
-	err := g.client.Put(fmt.Sprintf("/v2/glossaries/%s", id), body, nil, nil, &glossary)
+	err := g.client.Put(ctx, fmt.Sprintf("/v2/glossaries/%s", id), body, nil, nil, &glossary)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update glossary: %w", err)
 	}
@@ -76,34 +99,64 @@ func (g *GlossariesService) Update(id, name string) (*Glossary, error) {
 }
 
 func (g *GlossariesService) ImportCsvFromPath(id string, csvPath string) (*GlossaryImport, error) {
-	return g.ImportCsvFromPathWithFormat(id, csvPath, GlossaryFileFormatCsvTableUni)
+	return g.ImportCsvFromPathCtx(context.Background(), id, csvPath)
+}
+
+func (g *GlossariesService) ImportCsvFromPathCtx(ctx context.Context, id string, csvPath string) (*GlossaryImport, error) {
+	return g.ImportCsvFromPathWithFormatCtx(ctx, id, csvPath, GlossaryFileFormatCsvTableUni)
 }
 
 func (g *GlossariesService) ImportCsvFromPathWithFormat(id string, csvPath string, contentType GlossaryFileFormat) (*GlossaryImport, error) {
-	return g.ImportCsvFromPathWithFormatAndCallback(id, csvPath, contentType, "")
+	return g.ImportCsvFromPathWithFormatCtx(context.Background(), id, csvPath, contentType)
+}
+
+func (g *GlossariesService) ImportCsvFromPathWithFormatCtx(ctx context.Context, id string, csvPath string, contentType GlossaryFileFormat) (*GlossaryImport, error) {
+	return g.ImportCsvFromPathWithFormatAndCallbackCtx(ctx, id, csvPath, contentType, "")
 }
 
 func (g *GlossariesService) ImportCsvFromPathWithFormatAndCallback(id string, csvPath string, contentType GlossaryFileFormat, callbackUrl string) (*GlossaryImport, error) {
+	return g.ImportCsvFromPathWithFormatAndCallbackCtx(context.Background(), id, csvPath, contentType, callbackUrl)
+}
+
+func (g *GlossariesService) ImportCsvFromPathWithFormatAndCallbackCtx(ctx context.Context, id string, csvPath string, contentType GlossaryFileFormat, callbackUrl string) (*GlossaryImport, error) {
 	file, err := os.Open(csvPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open CSV file: %w", err)
 	}
 	defer file.Close()
 
-	return g.ImportCsvWithFormatAndCallback(id, file, contentType, callbackUrl)
+	return g.ImportCsvWithFormatAndCallbackCtx(ctx, id, file, contentType, callbackUrl)
 }
 
 func (g *GlossariesService) ImportCsv(id string, csv *os.File) (*GlossaryImport, error) {
-	return g.ImportCsvWithFormat(id, csv, GlossaryFileFormatCsvTableUni)
+	return g.ImportCsvCtx(context.Background(), id, csv)
+}
+
+func (g *GlossariesService) ImportCsvCtx(ctx context.Context, id string, csv *os.File) (*GlossaryImport, error) {
+	return g.ImportCsvWithFormatCtx(ctx, id, csv, GlossaryFileFormatCsvTableUni)
 }
 
 func (g *GlossariesService) ImportCsvWithFormat(id string, csv *os.File, contentType GlossaryFileFormat) (*GlossaryImport, error) {
-	return g.ImportCsvWithFormatAndCallback(id, csv, contentType, "")
+	return g.ImportCsvWithFormatCtx(context.Background(), id, csv, contentType)
+}
+
+func (g *GlossariesService) ImportCsvWithFormatCtx(ctx context.Context, id string, csv *os.File, contentType GlossaryFileFormat) (*GlossaryImport, error) {
+	return g.ImportCsvWithFormatAndCallbackCtx(ctx, id, csv, contentType, "")
 }
 
 func (g *GlossariesService) ImportCsvWithFormatAndCallback(id string, csv *os.File, contentType GlossaryFileFormat, callbackUrl string) (*GlossaryImport, error) {
+	return g.ImportCsvWithFormatAndCallbackCtx(context.Background(), id, csv, contentType, callbackUrl)
+}
+
+func (g *GlossariesService) ImportCsvWithFormatAndCallbackCtx(ctx context.Context, id string, csv *os.File, contentType GlossaryFileFormat, callbackUrl string) (*GlossaryImport, error) {
+	return g.ImportCsvFromReader(ctx, id, NewUploadFile(csv, filepath.Base(csv.Name())), contentType, callbackUrl)
+}
+
+// ImportCsvFromReader imports CSV content that does not need to be on disk.
+// Gzip compression is detected from a ".gz" filename suffix.
+func (g *GlossariesService) ImportCsvFromReader(ctx context.Context, id string, csv *UploadFile, contentType GlossaryFileFormat, callbackUrl string) (*GlossaryImport, error) {
 	// Auto-detect gzip compression based on filename (like Java SDK)
-	fileName := csv.Name()
+	fileName := csv.Filename
 	isGzipped := strings.HasSuffix(strings.ToLower(fileName), ".gz")
 
 	body := map[string]interface{}{
@@ -116,12 +169,12 @@ func (g *GlossariesService) ImportCsvWithFormatAndCallback(id string, csv *os.Fi
 		body["callback_url"] = callbackUrl
 	}
 
-	files := map[string]*os.File{
+	files := map[string]*UploadFile{
 		"csv": csv,
 	}
 
 	var glossaryImport GlossaryImport
-	err := g.client.Post(fmt.Sprintf("/v2/glossaries/%s/import", id), body, files, nil, &glossaryImport)
+	err := g.client.Post(ctx, fmt.Sprintf("/v2/glossaries/%s/import", id), body, files, nil, &glossaryImport)
 	if err != nil {
 		return nil, fmt.Errorf("failed to import CSV to glossary: %w", err)
 	}
@@ -129,8 +182,12 @@ func (g *GlossariesService) ImportCsvWithFormatAndCallback(id string, csv *os.Fi
 }
 
 func (g *GlossariesService) GetImportStatus(id string) (*GlossaryImport, error) {
+	return g.GetImportStatusCtx(context.Background(), id)
+}
+
+func (g *GlossariesService) GetImportStatusCtx(ctx context.Context, id string) (*GlossaryImport, error) {
 	var glossaryImport GlossaryImport
-	err := g.client.Get(fmt.Sprintf("/v2/glossaries/imports/%s", id), nil, nil, &glossaryImport)
+	err := g.client.Get(ctx, fmt.Sprintf("/v2/glossaries/imports/%s", id), nil, nil, &glossaryImport)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get glossary import status: %w", err)
 	}
@@ -138,8 +195,12 @@ func (g *GlossariesService) GetImportStatus(id string) (*GlossaryImport, error)
 }
 
 func (g *GlossariesService) Counts(id string) (*GlossaryCounts, error) {
+	return g.CountsCtx(context.Background(), id)
+}
+
+func (g *GlossariesService) CountsCtx(ctx context.Context, id string) (*GlossaryCounts, error) {
 	var counts GlossaryCounts
-	err := g.client.Get(fmt.Sprintf("/v2/glossaries/%s/counts", id), nil, nil, &counts)
+	err := g.client.Get(ctx, fmt.Sprintf("/v2/glossaries/%s/counts", id), nil, nil, &counts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get glossary counts: %w", err)
 	}
@@ -147,6 +208,10 @@ func (g *GlossariesService) Counts(id string) (*GlossaryCounts, error) {
 }
 
 func (g *GlossariesService) Export(id string, contentType GlossaryFileFormat, source *string) ([]byte, error) {
+	return g.ExportCtx(context.Background(), id, contentType, source)
+}
+
+func (g *GlossariesService) ExportCtx(ctx context.Context, id string, contentType GlossaryFileFormat, source *string) ([]byte, error) {
 	params := map[string]string{
 		"content_type": string(contentType),
 	}
@@ -154,7 +219,7 @@ func (g *GlossariesService) Export(id string, contentType GlossaryFileFormat, so
 		params["source"] = *source
 	}
 
-	content, err := g.client.GetRaw(fmt.Sprintf("/v2/glossaries/%s/export", id), params, nil)
+	content, err := g.client.GetRaw(ctx, fmt.Sprintf("/v2/glossaries/%s/export", id), params, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to export glossary: %w", err)
 	}
@@ -162,6 +227,10 @@ func (g *GlossariesService) Export(id string, contentType GlossaryFileFormat, so
 }
 
 func (g *GlossariesService) ExportAsync(id, callbackUrl string, contentType GlossaryFileFormat, source *string) (*GlossaryExport, error) {
+	return g.ExportAsyncCtx(context.Background(), id, callbackUrl, contentType, source)
+}
+
+func (g *GlossariesService) ExportAsyncCtx(ctx context.Context, id, callbackUrl string, contentType GlossaryFileFormat, source *string) (*GlossaryExport, error) {
 	params := map[string]string{
 		"callback_url": callbackUrl,
 		"content_type": string(contentType),
@@ -171,7 +240,7 @@ func (g *GlossariesService) ExportAsync(id, callbackUrl string, contentType Glos
 	}
 
 	var glossaryExport GlossaryExport
-	err := g.client.Get(fmt.Sprintf("/v2/glossaries/%s/export/async", id), params, nil, &glossaryExport)
+	err := g.client.Get(ctx, fmt.Sprintf("/v2/glossaries/%s/export/async", id), params, nil, &glossaryExport)
 	if err != nil {
 		return nil, fmt.Errorf("failed to start glossary export: %w", err)
 	}
@@ -179,32 +248,33 @@ func (g *GlossariesService) ExportAsync(id, callbackUrl string, contentType Glos
 }
 
 func (g *GlossariesService) WaitForImport(glossaryImport *GlossaryImport, updateCallback func(*GlossaryImport), maxWaitTime *time.Duration) (*GlossaryImport, error) {
-	start := time.Now()
-	current := *glossaryImport
-
-	for current.Progress < 1.0 {
-		if maxWaitTime != nil && time.Since(start) > *maxWaitTime {
-			return &current, fmt.Errorf("timeout waiting for glossary import to complete")
-		}
-
-		time.Sleep(g.pollingInterval)
-
-		updated, err := g.GetImportStatus(current.ID)
-		if err != nil {
-			return &current, fmt.Errorf("failed to get import status: %w", err)
-		}
-
-		current = *updated
+	return g.WaitForImportCtx(context.Background(), glossaryImport, updateCallback, maxWaitTime)
+}
 
-		if updateCallback != nil {
-			updateCallback(&current)
-		}
+func (g *GlossariesService) WaitForImportCtx(ctx context.Context, glossaryImport *GlossaryImport, updateCallback func(*GlossaryImport), maxWaitTime *time.Duration) (*GlossaryImport, error) {
+	job := g.ImportJob(glossaryImport)
+	options := &WaitOptions{PollInterval: g.pollingInterval}
+	if maxWaitTime != nil {
+		options.MaxWaitTime = *maxWaitTime
+	}
+	if updateCallback != nil {
+		options.OnProgress = func(Job) { updateCallback(job.Import()) }
 	}
 
-	return &current, nil
+	err := job.Wait(ctx, options)
+	return job.Import(), err
+}
+
+// ImportJob returns a Job tracking the import.
+func (g *GlossariesService) ImportJob(glossaryImport *GlossaryImport) *ImportJob {
+	return newImportJob(glossaryImport, g.GetImportStatusCtx)
 }
 
 func (g *GlossariesService) AddOrReplaceEntry(id string, terms []GlossaryTerm, guid *string) (*GlossaryImport, error) {
+	return g.AddOrReplaceEntryCtx(context.Background(), id, terms, guid)
+}
+
+func (g *GlossariesService) AddOrReplaceEntryCtx(ctx context.Context, id string, terms []GlossaryTerm, guid *string) (*GlossaryImport, error) {
 	body := map[string]interface{}{
 		"terms": terms,
 	}
@@ -213,7 +283,7 @@ func (g *GlossariesService) AddOrReplaceEntry(id string, terms []GlossaryTerm, g
 	}
 
 	var glossaryImport GlossaryImport
-	err := g.client.Put(fmt.Sprintf("/v2/glossaries/%s/content", id), body, nil, nil, &glossaryImport)
+	err := g.client.Put(ctx, fmt.Sprintf("/v2/glossaries/%s/content", id), body, nil, nil, &glossaryImport)
 	if err != nil {
 		return nil, fmt.Errorf("failed to add or replace entry in glossary: %w", err)
 	}
@@ -221,6 +291,10 @@ func (g *GlossariesService) AddOrReplaceEntry(id string, terms []GlossaryTerm, g
 }
 
 func (g *GlossariesService) DeleteEntry(id string, term *GlossaryTerm, guid *string) (*GlossaryImport, error) {
+	return g.DeleteEntryCtx(context.Background(), id, term, guid)
+}
+
+func (g *GlossariesService) DeleteEntryCtx(ctx context.Context, id string, term *GlossaryTerm, guid *string) (*GlossaryImport, error) {
 	body := map[string]interface{}{}
 	if term != nil {
 		body["term"] = term
@@ -230,7 +304,7 @@ func (g *GlossariesService) DeleteEntry(id string, term *GlossaryTerm, guid *str
 	}
 
 	var glossaryImport GlossaryImport
-	err := g.client.Delete(fmt.Sprintf("/v2/glossaries/%s/content", id), body, nil, &glossaryImport)
+	err := g.client.Delete(ctx, fmt.Sprintf("/v2/glossaries/%s/content", id), body, nil, &glossaryImport)
 	if err != nil {
 		return nil, fmt.Errorf("failed to delete entry from glossary: %w", err)
 	}
@@ -3,70 +3,79 @@ package lara
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"crypto/hmac"
 	"crypto/md5"
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"mime"
-	"mime/multipart"
 	"net/http"
-	"net/textproto"
 	"net/url"
-	"os"
-	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 )
 
+// Client is safe for concurrent use by multiple goroutines. Token renewal is
+// single-flighted: when the token expires, only one goroutine contacts the
+// auth endpoint while the others wait for its result.
 type Client struct {
-	accessKey    *AccessKey
+	authenticator Authenticator
+
+	mu           sync.Mutex // guards token, refreshToken and renewal
 	token        string
 	refreshToken string
-	baseURL      string
-	httpClient   *http.Client
-	sdkName      string
-	sdkVersion   string
+	renewal      *tokenRenewal
+
+	tokenStore     TokenStore
+	onTokenRefresh func(*AuthToken)
+
+	baseURL     string
+	httpClient  *http.Client
+	retryPolicy *RetryPolicy
+	sdkName     string
+	sdkVersion  string
+}
+
+// tokenRenewal tracks an in-flight token renewal shared by concurrent requests.
+type tokenRenewal struct {
+	done chan struct{}
+	err  error
 }
 
 type authResponse struct {
 	Token string `json:"token"`
 }
 
-func newClient(auth interface{}, baseURL string) *Client {
+func newClient(auth Authenticator, baseURL string, httpClient *http.Client, retryPolicy *RetryPolicy) *Client {
 	client := &Client{
-		baseURL:    strings.TrimRight(baseURL, "/"),
-		httpClient: &http.Client{},
-		sdkName:    "lara-go",
-		sdkVersion: "1.5.1",
-	}
-
-	// Set authentication method based on type
-	switch a := auth.(type) {
-	case *AccessKey:
-		client.accessKey = a
-	case *Credentials:
-		// Backward compatibility with deprecated Credentials
-		client.accessKey = a.AccessKey
-	case *AuthToken:
+		authenticator: auth,
+		baseURL:       strings.TrimRight(baseURL, "/"),
+		httpClient:    httpClient,
+		retryPolicy:   retryPolicy,
+		sdkName:       "lara-go",
+		sdkVersion:    "1.5.1",
+	}
+
+	if a, ok := auth.(*AuthToken); ok {
 		// Use pre-existing token directly
 		client.token = a.Token
 		client.refreshToken = a.RefreshToken
 	}
-	// If auth is nil or unknown type, accessKey remains nil
 
 	return client
 }
 
-// isTokenExpired checks if the current JWT token is expired or about to expire.
-func (c *Client) isTokenExpired() bool {
-	if c.token == "" {
+// isTokenExpired checks if a JWT token is expired or about to expire.
+func isTokenExpired(token string) bool {
+	if token == "" {
 		return true
 	}
 
-	parts := strings.Split(c.token, ".")
+	parts := strings.Split(token, ".")
 	if len(parts) != 3 {
 		return true
 	}
@@ -95,23 +104,23 @@ func (c *Client) isTokenExpired() bool {
 }
 
 // authenticateWithAccessKey authenticates using access key with challenge-response
-func (c *Client) authenticateWithAccessKey() error {
+func (c *Client) authenticateWithAccessKey(ctx context.Context, accessKey *AccessKey) (*AuthToken, error) {
 	path := "/v2/auth"
 	method := "POST"
 
 	authData := map[string]string{
-		"id": c.accessKey.ID,
+		"id": accessKey.ID,
 	}
 
 	bodyBytes, err := json.Marshal(authData)
 	if err != nil {
-		return fmt.Errorf("failed to marshal auth request: %w", err)
+		return nil, fmt.Errorf("failed to marshal auth request: %w", err)
 	}
 
 	reqURL := c.baseURL + path
-	req, err := http.NewRequest(method, reqURL, bytes.NewReader(bodyBytes))
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, bytes.NewReader(bodyBytes))
 	if err != nil {
-		return fmt.Errorf("failed to create auth request: %w", err)
+		return nil, fmt.Errorf("failed to create auth request: %w", err)
 	}
 
 	// Calculate MD5 hash of body
@@ -126,104 +135,254 @@ func (c *Client) authenticateWithAccessKey() error {
 	req.Header.Set("X-Lara-SDK-Version", c.sdkVersion)
 
 	// Sign request with HMAC for authentication
-	signature := c.sign(method, path, contentMD5, contentType, req.Header.Get("X-Lara-Date"))
+	signature := sign(accessKey.Secret, method, path, contentMD5, contentType, req.Header.Get("X-Lara-Date"))
 	req.Header.Set("Authorization", fmt.Sprintf("Lara:%s", signature))
 
+	return c.doAuthRequest(ctx, req)
+}
+
+// doAuthRequest sends an authentication request and reads the issued token pair.
+func (c *Client) doAuthRequest(ctx context.Context, req *http.Request) (*AuthToken, error) {
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to execute auth request: %w", err)
+		return nil, fmt.Errorf("failed to execute auth request: %w", transportError(ctx, err))
 	}
 	defer resp.Body.Close()
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("failed to read auth response: %w", err)
+		return nil, fmt.Errorf("failed to read auth response: %w", err)
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf("authentication failed: %s", string(respBody))
+		return nil, fmt.Errorf("authentication failed: %w", parseAPIError(resp.StatusCode, resp.Header, respBody))
 	}
 
 	var authResp authResponse
 	if err := json.Unmarshal(respBody, &authResp); err != nil {
-		return fmt.Errorf("failed to parse auth response: %w", err)
+		return nil, fmt.Errorf("failed to parse auth response: %w", err)
 	}
 
-	c.token = authResp.Token
-	c.refreshToken = resp.Header.Get("x-lara-refresh-token")
-
-	return nil
+	return &AuthToken{
+		Token:        authResp.Token,
+		RefreshToken: resp.Header.Get("x-lara-refresh-token"),
+	}, nil
 }
 
-// refreshTokens refreshes the JWT token using the refresh token.
-func (c *Client) refreshTokens() error {
+// refreshTokens exchanges refreshToken for a new JWT token.
+func (c *Client) refreshTokens(ctx context.Context, refreshToken string) (*AuthToken, error) {
 	path := "/v2/auth/refresh"
 	method := "POST"
 
 	reqURL := c.baseURL + path
-	req, err := http.NewRequest(method, reqURL, nil)
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, nil)
 	if err != nil {
-		return fmt.Errorf("failed to create refresh request: %w", err)
+		return nil, fmt.Errorf("failed to create refresh request: %w", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.refreshToken)
+	req.Header.Set("Authorization", "Bearer "+refreshToken)
 	req.Header.Set("X-Lara-SDK-Name", c.sdkName)
 	req.Header.Set("X-Lara-SDK-Version", c.sdkVersion)
 	req.Header.Set("X-Lara-Date", c.httpDate())
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to execute refresh request: %w", err)
+		return nil, fmt.Errorf("failed to execute refresh request: %w", transportError(ctx, err))
 	}
 	defer resp.Body.Close()
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("failed to read refresh response: %w", err)
+		return nil, fmt.Errorf("failed to read refresh response: %w", err)
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf("refresh failed: %s", string(respBody))
+		return nil, fmt.Errorf("refresh failed: %w", parseAPIError(resp.StatusCode, resp.Header, respBody))
 	}
 
 	var authResp authResponse
 	if err := json.Unmarshal(respBody, &authResp); err != nil {
-		return fmt.Errorf("failed to parse refresh response: %w", err)
+		return nil, fmt.Errorf("failed to parse refresh response: %w", err)
 	}
 
-	c.token = authResp.Token
+	tokens := &AuthToken{Token: authResp.Token, RefreshToken: refreshToken}
 	if newRefreshToken := resp.Header.Get("x-lara-refresh-token"); newRefreshToken != "" {
-		c.refreshToken = newRefreshToken
+		tokens.RefreshToken = newRefreshToken
 	}
 
-	return nil
+	return tokens, nil
 }
 
 // refreshOrReauthenticate tries to refresh the token first, falls back to full authentication.
-func (c *Client) refreshOrReauthenticate() error {
-	if c.refreshToken != "" {
-		if err := c.refreshTokens(); err != nil {
-			c.refreshToken = ""
-			if c.accessKey == nil {
-				return err
-			}
-		} else {
+func (c *Client) refreshOrReauthenticate(ctx context.Context, refreshToken string) (*AuthToken, error) {
+	var refreshErr error
+	if refreshToken != "" {
+		tokens, err := c.refreshTokens(ctx, refreshToken)
+		if err == nil {
+			return tokens, nil
+		}
+		refreshErr = err
+	}
+
+	if c.authenticator == nil {
+		return nil, fmt.Errorf("%w: no authentication method available for token renewal", ErrAuthentication)
+	}
+
+	tokens, err := c.authenticator.Authenticate(ctx, &AuthClient{client: c})
+	if err != nil {
+		if refreshErr != nil {
+			return nil, fmt.Errorf("%v; %w", refreshErr, err)
+		}
+		return nil, err
+	}
+	if tokens == nil || tokens.Token == "" {
+		return nil, fmt.Errorf("%w: authenticator returned no token", ErrAuthentication)
+	}
+
+	return tokens, nil
+}
+
+// validToken returns the current token, renewing it first if it is missing or expired.
+func (c *Client) validToken(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	token := c.token
+	c.mu.Unlock()
+
+	if !isTokenExpired(token) {
+		return token, nil
+	}
+
+	if err := c.renewToken(ctx, token); err != nil {
+		return "", err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.token, nil
+}
+
+// renewToken replaces the stale token with a fresh one. If another goroutine has
+// already replaced it, renewToken returns immediately; if a renewal is in flight,
+// it waits for that renewal instead of starting a new one.
+func (c *Client) renewToken(ctx context.Context, stale string) error {
+	for {
+		c.mu.Lock()
+		if c.token != stale && !isTokenExpired(c.token) {
+			c.mu.Unlock()
 			return nil
 		}
+
+		if renewal := c.renewal; renewal != nil {
+			c.mu.Unlock()
+			select {
+			case <-renewal.done:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			// The renewing goroutine may have given up because its own context
+			// ended; in that case try again with ours.
+			if errors.Is(renewal.err, context.Canceled) || errors.Is(renewal.err, context.DeadlineExceeded) {
+				continue
+			}
+			return renewal.err
+		}
+
+		renewal := &tokenRenewal{done: make(chan struct{})}
+		c.renewal = renewal
+		refreshToken := c.refreshToken
+		c.mu.Unlock()
+
+		tokens, renewed, err := c.obtainTokens(ctx, stale, refreshToken)
+		if err != nil && ctx.Err() != nil {
+			err = ctx.Err()
+		}
+
+		c.mu.Lock()
+		if tokens != nil {
+			c.token = tokens.Token
+			c.refreshToken = tokens.RefreshToken
+		}
+		c.renewal = nil
+		c.mu.Unlock()
+
+		if renewed && c.onTokenRefresh != nil {
+			c.onTokenRefresh(NewAuthToken(tokens.Token, tokens.RefreshToken))
+		}
+
+		renewal.err = err
+		close(renewal.done)
+		return err
+	}
+}
+
+// obtainTokens returns a token to replace stale. With a token store, it first
+// adopts tokens saved by another client or process, and persists the tokens it
+// renews itself; renewed reports whether new tokens were issued. A store that
+// is a TokenLocker stays locked throughout, so only one process renews at once.
+// If the tokens were renewed but could not be saved, they are returned together
+// with the error.
+func (c *Client) obtainTokens(ctx context.Context, stale, refreshToken string) (tokens *AuthToken, renewed bool, err error) {
+	if c.tokenStore == nil {
+		tokens, err := c.refreshOrReauthenticate(ctx, refreshToken)
+		return tokens, err == nil, err
+	}
+
+	if locker, ok := c.tokenStore.(TokenLocker); ok {
+		unlock, err := locker.Lock(ctx)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to lock token store: %w", err)
+		}
+		defer unlock()
+	}
+
+	stored, err := c.tokenStore.Load(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to load tokens: %w", err)
+	}
+	if stored != nil {
+		if stored.Token != "" && stored.Token != stale && !isTokenExpired(stored.Token) {
+			return stored, false, nil
+		}
+		// The stored refresh token may have been rotated after ours was issued.
+		if stored.RefreshToken != "" {
+			refreshToken = stored.RefreshToken
+		}
 	}
 
-	if c.accessKey != nil {
-		return c.authenticateWithAccessKey()
+	tokens, err = c.refreshOrReauthenticate(ctx, refreshToken)
+	if err != nil {
+		return nil, false, err
+	}
+	if err := c.tokenStore.Save(ctx, tokens); err != nil {
+		return tokens, true, fmt.Errorf("failed to save tokens: %w", err)
 	}
+	return tokens, true, nil
+}
 
-	return fmt.Errorf("no authentication method available for token renewal")
+func (c *Client) request(ctx context.Context, method, path string, params map[string]string, body interface{}, files map[string]*UploadFile, headers map[string]string) ([]byte, error) {
+	respBody, _, err := c.requestWithHeader(ctx, method, path, params, body, files, headers)
+	return respBody, err
 }
 
-func (c *Client) request(method, path string, params map[string]string, body interface{}, files map[string]*os.File, headers map[string]string) ([]byte, error) {
-	return c.doRequest(method, path, params, body, files, headers, 0)
+// requestWithHeader is like request but also returns the response headers.
+func (c *Client) requestWithHeader(ctx context.Context, method, path string, params map[string]string, body interface{}, files map[string]*UploadFile, headers map[string]string) ([]byte, http.Header, error) {
+	payload, err := newRequestBody(body, files)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer payload.close()
+
+	var respBody []byte
+	var respHeader http.Header
+	err = c.retryPolicy.run(ctx, isIdempotent(method, headers), func() error {
+		var err error
+		respBody, respHeader, err = c.doRequest(ctx, method, path, params, payload, headers, 0)
+		return err
+	})
+	return respBody, respHeader, err
 }
 
-func (c *Client) doRequest(method, path string, params map[string]string, body interface{}, files map[string]*os.File, headers map[string]string, retryCount int) ([]byte, error) {
+func (c *Client) doRequest(ctx context.Context, method, path string, params map[string]string, payload *requestBody, headers map[string]string, retryCount int) ([]byte, http.Header, error) {
 	if !strings.HasPrefix(path, "/") {
 		path = "/" + path
 	}
@@ -237,73 +396,28 @@ func (c *Client) doRequest(method, path string, params map[string]string, body i
 		reqURL += "?" + values.Encode()
 	}
 
-	var bodyReader io.Reader
 	var contentMD5, contentType string
+	var contentLength int64
 
-	if len(files) > 0 {
-		// Multipart form
-		var b bytes.Buffer
-		w := multipart.NewWriter(&b)
-		for field, f := range files {
-			filename := filepath.Base(f.Name())
-			mimeType := mime.TypeByExtension(filepath.Ext(filename))
-			if mimeType == "" {
-				mimeType = "application/octet-stream"
-			}
-
-			h := make(textproto.MIMEHeader)
-			h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`, field, filename))
-			h.Set("Content-Type", mimeType)
-
-			fw, err := w.CreatePart(h)
-			if err != nil {
-				return nil, fmt.Errorf("failed to create form file: %w", err)
-			}
-			if _, err := io.Copy(fw, f); err != nil {
-				return nil, fmt.Errorf("failed to copy file data: %w", err)
-			}
-		}
-		if body != nil {
-			if bodyMap, ok := body.(map[string]interface{}); ok {
-				for key, value := range bodyMap {
-					switch v := value.(type) {
-					case string:
-						_ = w.WriteField(key, v)
-					default:
-						jsonBytes, err := json.Marshal(v)
-						if err != nil {
-							return nil, fmt.Errorf("failed to marshal field %s: %w", key, err)
-						}
-						_ = w.WriteField(key, string(jsonBytes))
-					}
-				}
-			} else if bodyMap, ok := body.(map[string]string); ok {
-				for key, value := range bodyMap {
-					_ = w.WriteField(key, value)
-				}
-			}
-		}
-		w.Close()
-		bodyBytes := b.Bytes()
-		bodyReader = bytes.NewReader(bodyBytes)
-		contentType = w.FormDataContentType()
-		hash := md5.Sum(bodyBytes)
-		contentMD5 = fmt.Sprintf("%x", hash)
-	} else if body != nil {
-
-		bodyBytes, err := json.Marshal(body)
+	if payload != nil {
+		hash, size, err := payload.digest()
 		if err != nil {
-			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+			return nil, nil, err
 		}
-		bodyReader = bytes.NewReader(bodyBytes)
-		contentType = "application/json"
-		hash := md5.Sum(bodyBytes)
-		contentMD5 = fmt.Sprintf("%x", hash)
+		contentType = payload.contentType
+		contentMD5 = hash
+		contentLength = size
+	}
+
+	// Ensure we have a valid, non-expired token before making the request
+	token, err := c.validToken(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("authentication failed: %w", err)
 	}
 
-	req, err := http.NewRequest(method, reqURL, bodyReader)
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("X-HTTP-Method-Override", method)
@@ -321,45 +435,54 @@ func (c *Client) doRequest(method, path string, params map[string]string, body i
 		req.Header.Set(k, v)
 	}
 
-	// Ensure we have a valid, non-expired token before making the request
-	if c.isTokenExpired() {
-		c.token = ""
-		if err := c.refreshOrReauthenticate(); err != nil {
-			return nil, fmt.Errorf("authentication failed: %w", err)
-		}
-	}
-
 	// Use JWT Bearer token for authorization
-	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	// The body is created last: the transport closes it once Do is called,
+	// while a multipart reader dropped before that would block its writer.
+	if payload != nil {
+		req.Body = payload.reader()
+		req.GetBody = func() (io.ReadCloser, error) { return payload.reader(), nil }
+		req.ContentLength = contentLength
+	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		if strings.Contains(err.Error(), "timeout") {
-			return nil, &LaraTimeoutError{Message: err.Error()}
-		}
-		return nil, &LaraConnectionError{Message: err.Error()}
+		return nil, nil, transportError(ctx, err)
 	}
 	defer resp.Body.Close()
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, nil, fmt.Errorf("failed to read response body: %w", transportError(ctx, err))
 	}
 
 	// Handle 401 with automatic token refresh and retry (once)
 	if resp.StatusCode == 401 && retryCount < 1 {
-		c.token = ""
-		if err := c.refreshOrReauthenticate(); err != nil {
-			return nil, fmt.Errorf("token refresh failed: %w", err)
+		if err := c.renewToken(ctx, token); err != nil {
+			return nil, nil, fmt.Errorf("token refresh failed: %w", err)
 		}
-		return c.doRequest(method, path, params, body, files, headers, retryCount+1)
+		return c.doRequest(ctx, method, path, params, payload, headers, retryCount+1)
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, parseAPIError(resp.StatusCode, respBody)
+		return nil, nil, parseAPIError(resp.StatusCode, resp.Header, respBody)
 	}
 
-	return respBody, nil
+	return respBody, resp.Header, nil
+}
+
+// sleepContext pauses for d, returning early with the context error if ctx is done first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
 }
 
 func (c *Client) httpDate() string {
@@ -367,7 +490,7 @@ func (c *Client) httpDate() string {
 }
 
 // sign creates the HMAC signature for authentication
-func (c *Client) sign(method, path, contentMD5, contentType, date string) string {
+func sign(secret, method, path, contentMD5, contentType, date string) string {
 	stringToSign := fmt.Sprintf("%s\n%s\n%s\n%s\n%s",
 		strings.ToUpper(method),
 		path,
@@ -376,29 +499,39 @@ func (c *Client) sign(method, path, contentMD5, contentType, date string) string
 		date,
 	)
 
-	mac := hmac.New(sha256.New, []byte(c.accessKey.Secret))
+	mac := hmac.New(sha256.New, []byte(secret))
 	mac.Write([]byte(stringToSign))
 	signature := mac.Sum(nil)
 
 	return base64.StdEncoding.EncodeToString(signature)
 }
 
-func parseAPIError(statusCode int, body []byte) *LaraError {
+func parseAPIError(statusCode int, header http.Header, body []byte) *LaraError {
+	retryAfter := parseRetryAfter(header.Get("Retry-After"))
+	requestID := header.Get("X-Lara-Request-Id")
+	if requestID == "" {
+		requestID = header.Get("X-Request-Id")
+	}
+
 	var apiError struct {
 		Type    string `json:"type"`
 		Message string `json:"message"`
 	}
 	if err := json.Unmarshal(body, &apiError); err == nil && apiError.Type != "" {
 		return &LaraError{
-			Status:  statusCode,
-			Type:    apiError.Type,
-			Message: apiError.Message,
+			Status:     statusCode,
+			Type:       apiError.Type,
+			Message:    apiError.Message,
+			RequestID:  requestID,
+			RetryAfter: retryAfter,
 		}
 	}
 	return &LaraError{
-		Status:  statusCode,
-		Type:    "UnknownError",
-		Message: "An unknown error occurred",
+		Status:     statusCode,
+		Type:       "UnknownError",
+		Message:    "An unknown error occurred",
+		RequestID:  requestID,
+		RetryAfter: retryAfter,
 	}
 }
 
@@ -414,52 +547,56 @@ func (c *Client) handleContent(respBytes []byte, result interface{}) error {
 	return json.Unmarshal(respBytes, result)
 }
 
-func (c *Client) Get(path string, params map[string]string, headers map[string]string, result interface{}) error {
-	respBytes, err := c.request("GET", path, params, nil, nil, headers)
+func (c *Client) Get(ctx context.Context, path string, params map[string]string, headers map[string]string, result interface{}) error {
+	respBytes, err := c.request(ctx, "GET", path, params, nil, nil, headers)
 	if err != nil {
 		return err
 	}
 	return c.handleContent(respBytes, result)
 }
 
-func (c *Client) Post(path string, body interface{}, files map[string]*os.File, headers map[string]string, result interface{}) error {
-	respBytes, err := c.request("POST", path, nil, body, files, headers)
+func (c *Client) Post(ctx context.Context, path string, body interface{}, files map[string]*UploadFile, headers map[string]string, result interface{}) error {
+	respBytes, err := c.request(ctx, "POST", path, nil, body, files, headers)
 	if err != nil {
 		return err
 	}
 	return c.handleContent(respBytes, result)
 }
 
-func (c *Client) Put(path string, body interface{}, files map[string]*os.File, headers map[string]string, result interface{}) error {
-	respBytes, err := c.request("PUT", path, nil, body, files, headers)
+func (c *Client) Put(ctx context.Context, path string, body interface{}, files map[string]*UploadFile, headers map[string]string, result interface{}) error {
+	respBytes, err := c.request(ctx, "PUT", path, nil, body, files, headers)
 	if err != nil {
 		return err
 	}
 	return c.handleContent(respBytes, result)
 }
 
-func (c *Client) Delete(path string, body interface{}, headers map[string]string, result interface{}) error {
-	respBytes, err := c.request("DELETE", path, nil, body, nil, headers)
+func (c *Client) Delete(ctx context.Context, path string, body interface{}, headers map[string]string, result interface{}) error {
+	respBytes, err := c.request(ctx, "DELETE", path, nil, body, nil, headers)
 	if err != nil {
 		return err
 	}
 	return c.handleContent(respBytes, result)
 }
 
-func (c *Client) PostRaw(path string, body interface{}, files map[string]*os.File, headers map[string]string) ([]byte, error) {
-	return c.request("POST", path, nil, body, files, headers)
+func (c *Client) PostRaw(ctx context.Context, path string, body interface{}, files map[string]*UploadFile, headers map[string]string) ([]byte, error) {
+	return c.request(ctx, "POST", path, nil, body, files, headers)
 }
 
-func (c *Client) GetRaw(path string, params map[string]string, headers map[string]string) ([]byte, error) {
-	return c.request("GET", path, params, nil, nil, headers)
+func (c *Client) GetRaw(ctx context.Context, path string, params map[string]string, headers map[string]string) ([]byte, error) {
+	return c.request(ctx, "GET", path, params, nil, nil, headers)
 }
 
 // PostAndGetStream makes a POST request and processes the response as an NDJSON stream.
-func (c *Client) PostAndGetStream(path string, body interface{}, headers map[string]string, callback func([]byte) error) error {
-	return c.doPostAndGetStream(path, body, headers, callback, 0)
+// Failures are only retried before the stream starts, since the callback may already
+// have observed partial results afterwards.
+func (c *Client) PostAndGetStream(ctx context.Context, path string, body interface{}, headers map[string]string, callback func([]byte) error) error {
+	return c.retryPolicy.run(ctx, isIdempotent("POST", headers), func() error {
+		return c.doPostAndGetStream(ctx, path, body, headers, callback, 0)
+	})
 }
 
-func (c *Client) doPostAndGetStream(path string, body interface{}, headers map[string]string, callback func([]byte) error, retryCount int) error {
+func (c *Client) doPostAndGetStream(ctx context.Context, path string, body interface{}, headers map[string]string, callback func([]byte) error, retryCount int) error {
 	if !strings.HasPrefix(path, "/") {
 		path = "/" + path
 	}
@@ -480,7 +617,7 @@ func (c *Client) doPostAndGetStream(path string, body interface{}, headers map[s
 		contentMD5 = fmt.Sprintf("%x", hash)
 	}
 
-	req, err := http.NewRequest("POST", reqURL, bodyReader)
+	req, err := http.NewRequestWithContext(ctx, "POST", reqURL, bodyReader)
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
@@ -501,45 +638,42 @@ func (c *Client) doPostAndGetStream(path string, body interface{}, headers map[s
 	}
 
 	// Ensure we have a valid, non-expired token before making the request
-	if c.isTokenExpired() {
-		c.token = ""
-		if err := c.refreshOrReauthenticate(); err != nil {
-			return fmt.Errorf("authentication failed: %w", err)
-		}
+	token, err := c.validToken(ctx)
+	if err != nil {
+		return fmt.Errorf("authentication failed: %w", err)
 	}
 
 	// Use JWT Bearer token for authorization
-	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Authorization", "Bearer "+token)
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		if strings.Contains(err.Error(), "timeout") {
-			return &LaraTimeoutError{Message: err.Error()}
-		}
-		return &LaraConnectionError{Message: err.Error()}
+		return transportError(ctx, err)
 	}
 	defer resp.Body.Close()
 
 	// Handle 401 with automatic token refresh and retry (once)
 	if resp.StatusCode == 401 && retryCount < 1 {
-		c.token = ""
-		if err := c.refreshOrReauthenticate(); err != nil {
+		if err := c.renewToken(ctx, token); err != nil {
 			return fmt.Errorf("token refresh failed: %w", err)
 		}
-		return c.doPostAndGetStream(path, body, headers, callback, retryCount+1)
+		return c.doPostAndGetStream(ctx, path, body, headers, callback, retryCount+1)
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		respBody, _ := io.ReadAll(resp.Body)
-		return parseAPIError(resp.StatusCode, respBody)
+		return parseAPIError(resp.StatusCode, resp.Header, respBody)
 	}
 
 	// Read stream line by line (NDJSON format)
 	scanner := bufio.NewScanner(resp.Body)
 	for scanner.Scan() {
 		if err := processStreamLine(scanner.Bytes(), callback); err != nil {
-			return err
+			return &finalError{err}
 		}
 	}
-	return scanner.Err()
+	if err := scanner.Err(); err != nil {
+		return &finalError{fmt.Errorf("failed to read response stream: %w", transportError(ctx, err))}
+	}
+	return nil
 }
@@ -0,0 +1,190 @@
+package lara
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testJWT returns an unsigned JWT expiring at exp, tagged with a generation.
+func testJWT(exp time.Time, generation int64) string {
+	encode := base64.RawURLEncoding.EncodeToString
+	header := encode([]byte(`{"alg":"none"}`))
+	payload := encode([]byte(fmt.Sprintf(`{"exp":%d,"gen":%d}`, exp.Unix(), generation)))
+	return header + "." + payload + ".sig"
+}
+
+func tokenGeneration(token string) int64 {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return -1
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return -1
+	}
+	var claims struct {
+		Gen int64 `json:"gen"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return -1
+	}
+	return claims.Gen
+}
+
+// stubServer stands in for the Lara API. It counts the calls to the auth
+// endpoints and serves /v2/languages to tokens of the current generation;
+// revoke makes every token issued so far answer 401.
+type stubServer struct {
+	*httptest.Server
+
+	auths     int64
+	refreshes int64
+	rejected  int64
+
+	mu         sync.Mutex
+	generation int64
+	tokenTTL   time.Duration
+}
+
+func newStubServer(t *testing.T) *stubServer {
+	s := &stubServer{generation: 1, tokenTTL: time.Hour}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *stubServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/v2/auth":
+		atomic.AddInt64(&s.auths, 1)
+		s.issue(w)
+	case "/v2/auth/refresh":
+		atomic.AddInt64(&s.refreshes, 1)
+		s.issue(w)
+	case "/v2/languages":
+		s.mu.Lock()
+		generation := s.generation
+		s.mu.Unlock()
+
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if tokenGeneration(token) != generation {
+			atomic.AddInt64(&s.rejected, 1)
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"type":"AuthenticationError","message":"invalid token"}`)
+			return
+		}
+		fmt.Fprint(w, `["en-US","it-IT"]`)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *stubServer) issue(w http.ResponseWriter) {
+	s.mu.Lock()
+	token := testJWT(time.Now().Add(s.tokenTTL), s.generation)
+	s.mu.Unlock()
+
+	// Slow enough for concurrent callers to pile up behind the renewal.
+	time.Sleep(20 * time.Millisecond)
+	w.Header().Set("x-lara-refresh-token", "refresh")
+	fmt.Fprintf(w, `{"token":%q}`, token)
+}
+
+func (s *stubServer) revoke() {
+	s.mu.Lock()
+	s.generation++
+	s.mu.Unlock()
+}
+
+func newTestTranslator(t *testing.T, server *stubServer, auth Authenticator) *Translator {
+	translator, err := NewTranslator(auth, &TranslatorOptions{
+		ServerURL:   server.URL,
+		RetryPolicy: &RetryPolicy{MaxAttempts: 1},
+	})
+	if err != nil {
+		t.Fatalf("NewTranslator: %v", err)
+	}
+	return translator
+}
+
+// hammer calls LanguagesCtx from n goroutines. Each goroutine keeps calling
+// while more(call) returns true, call counting its own calls from 0.
+func hammer(t *testing.T, translator *Translator, n int, more func(call int) bool) {
+	var wg sync.WaitGroup
+	errs := make(chan error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for call := 0; more(call); call++ {
+				if _, err := translator.LanguagesCtx(context.Background()); err != nil {
+					errs <- err
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+func TestClientConcurrentLogin(t *testing.T) {
+	server := newStubServer(t)
+	translator := newTestTranslator(t, server, NewAccessKey("id", "secret"))
+
+	hammer(t, translator, 50, func(call int) bool { return call < 5 })
+
+	if auths := atomic.LoadInt64(&server.auths); auths != 1 {
+		t.Errorf("got %d calls to /v2/auth, want 1", auths)
+	}
+}
+
+func TestClientTokenExpiresUnderLoad(t *testing.T) {
+	server := newStubServer(t)
+	// isTokenExpired renews 5s ahead of exp, so this token lasts about a second.
+	auth := NewAuthToken(testJWT(time.Now().Add(6*time.Second), 1), "refresh")
+	translator := newTestTranslator(t, server, auth)
+
+	deadline := time.Now().Add(1500 * time.Millisecond)
+	hammer(t, translator, 50, func(int) bool { return time.Now().Before(deadline) })
+
+	if refreshes := atomic.LoadInt64(&server.refreshes); refreshes != 1 {
+		t.Errorf("got %d calls to /v2/auth/refresh, want 1", refreshes)
+	}
+	if auths := atomic.LoadInt64(&server.auths); auths != 0 {
+		t.Errorf("got %d calls to /v2/auth, want 0", auths)
+	}
+}
+
+func TestClientUnauthorizedMidFlight(t *testing.T) {
+	server := newStubServer(t)
+	translator := newTestTranslator(t, server, NewAccessKey("id", "secret"))
+
+	var calls int64
+	hammer(t, translator, 50, func(call int) bool {
+		if atomic.AddInt64(&calls, 1) == 100 {
+			server.revoke()
+		}
+		return call < 10
+	})
+
+	if atomic.LoadInt64(&server.rejected) == 0 {
+		t.Fatal("no request was rejected with 401")
+	}
+	// One login, then one renewal shared by every request that got a 401.
+	if renewals := atomic.LoadInt64(&server.auths) + atomic.LoadInt64(&server.refreshes); renewals != 2 {
+		t.Errorf("got %d calls to the auth endpoints, want 2", renewals)
+	}
+}
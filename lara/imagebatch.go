@@ -0,0 +1,200 @@
+package lara
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// ImageBatchMode selects what ImagesService.TranslateBatch produces.
+type ImageBatchMode string
+
+const (
+	// ImageBatchRender writes translated images, as Translate does.
+	ImageBatchRender ImageBatchMode = "render"
+	// ImageBatchText writes the translated text blocks as JSON, as TranslateText does.
+	ImageBatchText ImageBatchMode = "text"
+)
+
+// ImageBatchOptions configures ImagesService.TranslateBatch.
+type ImageBatchOptions struct {
+	// Targets lists the target languages; repeated ones are translated once. Required.
+	Targets []string
+	// Source is the source language; nil lets Lara detect it.
+	Source *string
+	// Mode defaults to ImageBatchRender.
+	Mode ImageBatchMode
+	// Concurrency bounds how many images are translated at once. Defaults to 4.
+	Concurrency int
+	// Translate holds the options of every render request.
+	Translate *ImageTranslateOptions
+	// TranslateText holds the options of every text request.
+	TranslateText *ImageTextTranslateOptions
+	// OnImageDone, if set, is called concurrently as each image and target finishes.
+	OnImageDone func(result ImageBatchResult)
+}
+
+// ImageBatchResult is the outcome of translating one image into one target language.
+type ImageBatchResult struct {
+	Input  string
+	Target string
+	// Output is the file written next to Input.
+	Output string
+	// Image is set in render mode. Its Data is released once written to Output.
+	Image *ImageTranslation
+	// Text is set in text mode.
+	Text *ImageTextResult
+	Err  error
+}
+
+// TranslateBatch translates every input image into every target language,
+// with at most Concurrency requests in flight. Each output is written next to
+// its input with the target language as a suffix: banner.png gives
+// banner.fr-FR.png in render mode and banner.fr-FR.json in text mode.
+//
+// Results follow the order of inputs, then of targets. A failed image only
+// sets the Err of its results; the returned error is only set when the batch
+// could not run or ctx ended.
+func (s *ImagesService) TranslateBatch(inputs []string, options *ImageBatchOptions) ([]ImageBatchResult, error) {
+	return s.TranslateBatchCtx(context.Background(), inputs, options)
+}
+
+func (s *ImagesService) TranslateBatchCtx(ctx context.Context, inputs []string, options *ImageBatchOptions) ([]ImageBatchResult, error) {
+	if options == nil || len(options.Targets) == 0 {
+		return nil, fmt.Errorf("%w: batch targets are required", ErrValidation)
+	}
+	mode := options.Mode
+	if mode == "" {
+		mode = ImageBatchRender
+	}
+	if mode != ImageBatchRender && mode != ImageBatchText {
+		return nil, fmt.Errorf("%w: unknown image batch mode %q", ErrValidation, mode)
+	}
+
+	concurrency := options.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultBatchConcurrency
+	}
+
+	// A repeated target would have two jobs writing the same output file.
+	var targets []string
+	seen := map[string]bool{}
+	for _, target := range options.Targets {
+		if !seen[target] {
+			seen[target] = true
+			targets = append(targets, target)
+		}
+	}
+
+	results := make([]ImageBatchResult, 0, len(inputs)*len(targets))
+	for _, input := range inputs {
+		for _, target := range targets {
+			results = append(results, ImageBatchResult{
+				Input:  input,
+				Target: target,
+				Output: imageBatchOutput(input, target, mode, ""),
+			})
+		}
+	}
+
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i := range results {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(result *ImageBatchResult) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if mode == ImageBatchText {
+				result.Err = s.translateBatchText(ctx, result, options)
+			} else {
+				result.Err = s.translateBatchImage(ctx, result, options)
+			}
+			if options.OnImageDone != nil {
+				options.OnImageDone(*result)
+			}
+		}(&results[i])
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		for i := range results {
+			if results[i].Err == nil && results[i].Image == nil && results[i].Text == nil {
+				results[i].Err = err
+			}
+		}
+		return results, err
+	}
+	return results, nil
+}
+
+func (s *ImagesService) translateBatchImage(ctx context.Context, result *ImageBatchResult, options *ImageBatchOptions) error {
+	file, err := os.Open(result.Input)
+	if err != nil {
+		return fmt.Errorf("failed to open image file: %w", err)
+	}
+	defer file.Close()
+
+	translated, err := s.TranslateFromReader(ctx, NewUploadFile(file, filepath.Base(result.Input)), options.Source, result.Target, options.Translate)
+	if err != nil {
+		return err
+	}
+
+	result.Output = imageBatchOutput(result.Input, result.Target, ImageBatchRender, translated.Extension())
+	if err := writeFileAtomic(result.Output, bytes.NewReader(translated.Data)); err != nil {
+		return fmt.Errorf("failed to write translated image: %w", err)
+	}
+	translated.Data = nil
+	result.Image = translated
+	return nil
+}
+
+func (s *ImagesService) translateBatchText(ctx context.Context, result *ImageBatchResult, options *ImageBatchOptions) error {
+	file, err := os.Open(result.Input)
+	if err != nil {
+		return fmt.Errorf("failed to open image file: %w", err)
+	}
+	defer file.Close()
+
+	translated, err := s.TranslateTextFromReader(ctx, NewUploadFile(file, filepath.Base(result.Input)), options.Source, result.Target, options.TranslateText)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(translated, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal image text: %w", err)
+	}
+	if err := writeFileAtomic(result.Output, bytes.NewReader(data)); err != nil {
+		return fmt.Errorf("failed to write image text: %w", err)
+	}
+	result.Text = translated
+	return nil
+}
+
+// imageBatchOutput names the output of input for target. In render mode the
+// extension of the translated image is used when known, else the input's.
+func imageBatchOutput(input, target string, mode ImageBatchMode, extension string) string {
+	ext := filepath.Ext(input)
+	base := strings.TrimSuffix(input, ext)
+	if mode == ImageBatchText {
+		return base + "." + target + ".json"
+	}
+	if extension != "" && !(strings.EqualFold(ext, ".jpeg") && extension == ".jpg") {
+		ext = extension
+	}
+	return base + "." + target + ext
+}
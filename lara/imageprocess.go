@@ -0,0 +1,276 @@
+package lara
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+const defaultJPEGQuality = 85
+
+// ImagePreprocessOptions configures the processing applied to an image before
+// it is uploaded. The image is always rotated upright according to its EXIF
+// orientation and re-encoded, which drops EXIF, GPS and other metadata.
+type ImagePreprocessOptions struct {
+	// MaxDimension bounds the longer side of the image, in pixels; larger
+	// images are downscaled. Zero keeps the original size.
+	MaxDimension int
+	// Format is the format the image is re-encoded to, ImageFormatPng or
+	// ImageFormatJpeg. Defaults to JPEG for JPEG input and PNG otherwise.
+	Format ImageFormat
+	// JPEGQuality is the JPEG quality, from 1 to 100. Defaults to 85.
+	JPEGQuality int
+}
+
+// ImagePreprocessResult reports what preprocessing did to an image.
+type ImagePreprocessResult struct {
+	OriginalWidth  int
+	OriginalHeight int
+	// Width and Height are the dimensions of the uploaded image, after rotation and scaling.
+	Width  int
+	Height int
+	// Orientation is the EXIF orientation that was applied, 1 when none.
+	Orientation  int
+	OriginalSize int64
+	Size         int64
+	Format       ImageFormat
+}
+
+// PreprocessImage decodes a PNG, JPEG or GIF image, applies its EXIF
+// orientation, downscales it to options.MaxDimension and re-encodes it
+// without metadata. Images in other formats fail with an error matching
+// ErrValidation.
+func PreprocessImage(r io.Reader, options *ImagePreprocessOptions) ([]byte, *ImagePreprocessResult, error) {
+	if options == nil {
+		options = &ImagePreprocessOptions{}
+	}
+	if options.Format != "" && options.Format != ImageFormatPng && options.Format != ImageFormatJpeg {
+		return nil, nil, fmt.Errorf("%w: images can only be preprocessed into png or jpeg, not %q", ErrValidation, options.Format)
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read image: %w", err)
+	}
+
+	src, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: cannot decode image: %v", ErrValidation, err)
+	}
+
+	result := &ImagePreprocessResult{
+		OriginalWidth:  src.Bounds().Dx(),
+		OriginalHeight: src.Bounds().Dy(),
+		Orientation:    1,
+		OriginalSize:   int64(len(data)),
+		Format:         options.Format,
+	}
+	if result.Format == "" {
+		result.Format = ImageFormatPng
+		if format == "jpeg" {
+			result.Format = ImageFormatJpeg
+		}
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, result.OriginalWidth, result.OriginalHeight))
+	draw.Draw(img, img.Bounds(), src, src.Bounds().Min, draw.Src)
+
+	if format == "jpeg" {
+		if orientation := exifOrientation(data); orientation > 1 && orientation <= 8 {
+			img = orient(img, orientation)
+			result.Orientation = orientation
+		}
+	}
+
+	if limit := options.MaxDimension; limit > 0 {
+		w, h := img.Bounds().Dx(), img.Bounds().Dy()
+		if w > limit || h > limit {
+			if w >= h {
+				w, h = limit, h*limit/w
+			} else {
+				w, h = w*limit/h, limit
+			}
+			if w < 1 {
+				w = 1
+			}
+			if h < 1 {
+				h = 1
+			}
+			img = downscale(img, w, h)
+		}
+	}
+	result.Width, result.Height = img.Bounds().Dx(), img.Bounds().Dy()
+
+	var out bytes.Buffer
+	if result.Format == ImageFormatJpeg {
+		quality := options.JPEGQuality
+		if quality <= 0 || quality > 100 {
+			quality = defaultJPEGQuality
+		}
+		err = jpeg.Encode(&out, img, &jpeg.Options{Quality: quality})
+	} else {
+		err = png.Encode(&out, img)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode image: %w", err)
+	}
+
+	result.Size = int64(out.Len())
+	return out.Bytes(), result, nil
+}
+
+// preprocessUpload replaces image with its preprocessed version, renamed to
+// the extension of the new format.
+func preprocessUpload(image *UploadFile, options *ImagePreprocessOptions) (*UploadFile, *ImagePreprocessResult, error) {
+	if image == nil || image.Reader == nil {
+		return nil, nil, fmt.Errorf("upload file has no content")
+	}
+
+	data, result, err := PreprocessImage(image.Reader, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	filename := strings.TrimSuffix(image.Filename, filepath.Ext(image.Filename)) + result.Format.Extension()
+	return NewUploadFileFromBytes(data, filename), result, nil
+}
+
+// exifOrientation returns the orientation tag of the EXIF block of a JPEG
+// file, or 0 if it has none.
+func exifOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xff || data[1] != 0xd8 {
+		return 0
+	}
+
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xff {
+			return 0
+		}
+		marker := data[i+1]
+		if marker == 0xda || marker == 0xd9 {
+			// Start of scan or end of image: no more metadata.
+			return 0
+		}
+		length := int(binary.BigEndian.Uint16(data[i+2 : i+4]))
+		if length < 2 || i+2+length > len(data) {
+			return 0
+		}
+		segment := data[i+4 : i+2+length]
+		if marker == 0xe1 && len(segment) > 6 && string(segment[0:6]) == "Exif\x00\x00" {
+			return tiffOrientation(segment[6:])
+		}
+		i += 2 + length
+	}
+	return 0
+}
+
+// tiffOrientation reads tag 0x0112 from the first IFD of a TIFF header.
+func tiffOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 0
+	}
+	var order binary.ByteOrder
+	switch string(tiff[0:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 0
+	}
+
+	ifd := int(order.Uint32(tiff[4:8]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return 0
+	}
+	count := int(order.Uint16(tiff[ifd : ifd+2]))
+	for i := 0; i < count; i++ {
+		entry := ifd + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 0
+		}
+		if order.Uint16(tiff[entry:entry+2]) == 0x0112 {
+			return int(order.Uint16(tiff[entry+8 : entry+10]))
+		}
+	}
+	return 0
+}
+
+// orient returns src transformed so that it displays upright, given its EXIF orientation.
+func orient(src *image.RGBA, orientation int) *image.RGBA {
+	w, h := src.Bounds().Dx(), src.Bounds().Dy()
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+
+	for y := 0; y < dh; y++ {
+		for x := 0; x < dw; x++ {
+			sx, sy := x, y
+			switch orientation {
+			case 2: // mirrored horizontally
+				sx, sy = w-1-x, y
+			case 3: // rotated 180°
+				sx, sy = w-1-x, h-1-y
+			case 4: // mirrored vertically
+				sx, sy = x, h-1-y
+			case 5: // transposed
+				sx, sy = y, x
+			case 6: // rotated 90° clockwise
+				sx, sy = y, h-1-x
+			case 7: // transversed
+				sx, sy = w-1-y, h-1-x
+			case 8: // rotated 90° counterclockwise
+				sx, sy = w-1-y, x
+			}
+			copy(dst.Pix[y*dst.Stride+x*4:y*dst.Stride+x*4+4], src.Pix[sy*src.Stride+sx*4:sy*src.Stride+sx*4+4])
+		}
+	}
+	return dst
+}
+
+// downscale shrinks src to w×h by averaging the source pixels covered by
+// each destination pixel.
+func downscale(src *image.RGBA, w, h int) *image.RGBA {
+	sw, sh := src.Bounds().Dx(), src.Bounds().Dy()
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+
+	for y := 0; y < h; y++ {
+		y0, y1 := y*sh/h, (y+1)*sh/h
+		if y1 <= y0 {
+			y1 = y0 + 1
+		}
+		for x := 0; x < w; x++ {
+			x0, x1 := x*sw/w, (x+1)*sw/w
+			if x1 <= x0 {
+				x1 = x0 + 1
+			}
+
+			var sum [4]int
+			for sy := y0; sy < y1; sy++ {
+				row := src.Pix[sy*src.Stride+x0*4 : sy*src.Stride+x1*4]
+				for i := 0; i < len(row); i += 4 {
+					sum[0] += int(row[i])
+					sum[1] += int(row[i+1])
+					sum[2] += int(row[i+2])
+					sum[3] += int(row[i+3])
+				}
+			}
+
+			n := (y1 - y0) * (x1 - x0)
+			offset := y*dst.Stride + x*4
+			for c := 0; c < 4; c++ {
+				dst.Pix[offset+c] = uint8((sum[c] + n/2) / n)
+			}
+		}
+	}
+	return dst
+}
@@ -0,0 +1,125 @@
+package lara
+
+import (
+	"encoding/binary"
+	"image"
+	"image/color"
+	"testing"
+)
+
+// labelled returns a w×h image whose pixels are the letters of labels,
+// row by row, in the red channel.
+func labelled(w, h int, labels string) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for i := 0; i < len(labels); i++ {
+		img.Set(i%w, i/w, color.RGBA{R: labels[i], A: 255})
+	}
+	return img
+}
+
+func labels(img *image.RGBA) string {
+	var out []byte
+	for y := 0; y < img.Bounds().Dy(); y++ {
+		for x := 0; x < img.Bounds().Dx(); x++ {
+			out = append(out, img.RGBAAt(x, y).R)
+		}
+	}
+	return string(out)
+}
+
+func TestOrient(t *testing.T) {
+	// The source is 2 wide and 3 tall:
+	//   A B
+	//   C D
+	//   E F
+	tests := []struct {
+		orientation int
+		w, h        int
+		want        string
+	}{
+		{1, 2, 3, "ABCDEF"},
+		{2, 2, 3, "BADCFE"},
+		{3, 2, 3, "FEDCBA"},
+		{4, 2, 3, "EFCDAB"},
+		{5, 3, 2, "ACEBDF"},
+		{6, 3, 2, "ECAFDB"},
+		{7, 3, 2, "FDBECA"},
+		{8, 3, 2, "BDFACE"},
+	}
+
+	for _, tt := range tests {
+		got := orient(labelled(2, 3, "ABCDEF"), tt.orientation)
+		if w, h := got.Bounds().Dx(), got.Bounds().Dy(); w != tt.w || h != tt.h {
+			t.Errorf("orientation %d: got %d×%d, want %d×%d", tt.orientation, w, h, tt.w, tt.h)
+			continue
+		}
+		if layout := labels(got); layout != tt.want {
+			t.Errorf("orientation %d: got %s, want %s", tt.orientation, layout, tt.want)
+		}
+	}
+}
+
+// testJPEGWithExif returns the start of a JPEG file whose Exif segment,
+// written in the given byte order, records orientation.
+func testJPEGWithExif(order binary.ByteOrder, orientation uint16) []byte {
+	tiff := make([]byte, 8+2+2*12+4)
+	if order == binary.LittleEndian {
+		copy(tiff, "II")
+	} else {
+		copy(tiff, "MM")
+	}
+	order.PutUint16(tiff[2:4], 42)
+	order.PutUint32(tiff[4:8], 8)
+	order.PutUint16(tiff[8:10], 2)
+	// An unrelated tag, then orientation as a SHORT.
+	order.PutUint16(tiff[10:12], 0x010f)
+	order.PutUint16(tiff[22:24], 0x0112)
+	order.PutUint16(tiff[24:26], 3)
+	order.PutUint32(tiff[26:30], 1)
+	order.PutUint16(tiff[30:32], orientation)
+
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+	data := []byte{0xff, 0xd8, 0xff, 0xe0, 0, 4, 0, 0, 0xff, 0xe1, 0, 0}
+	binary.BigEndian.PutUint16(data[10:12], uint16(2+len(segment)))
+	data = append(data, segment...)
+	return append(data, 0xff, 0xda)
+}
+
+func TestExifOrientation(t *testing.T) {
+	for _, order := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+		for orientation := uint16(1); orientation <= 8; orientation++ {
+			if got := exifOrientation(testJPEGWithExif(order, orientation)); got != int(orientation) {
+				t.Errorf("%s, orientation %d: got %d", order, orientation, got)
+			}
+		}
+	}
+
+	data := testJPEGWithExif(binary.BigEndian, 6)
+	for n := 0; n < len(data)-2; n++ {
+		if got := exifOrientation(data[:n]); got != 0 {
+			t.Errorf("truncated to %d bytes: got %d, want 0", n, got)
+		}
+	}
+	if got := exifOrientation([]byte{0x89, 'P', 'N', 'G'}); got != 0 {
+		t.Errorf("PNG: got %d, want 0", got)
+	}
+}
+
+func TestDownscale(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	for x := 0; x < 4; x++ {
+		src.Set(x, 0, color.RGBA{R: uint8(x * 40), A: 255})
+		src.Set(x, 1, color.RGBA{R: uint8(x * 40), G: 200, A: 255})
+	}
+
+	got := downscale(src, 2, 1)
+	if w, h := got.Bounds().Dx(), got.Bounds().Dy(); w != 2 || h != 1 {
+		t.Fatalf("got %d×%d, want 2×1", w, h)
+	}
+	want := []color.RGBA{{R: 20, G: 100, A: 255}, {R: 100, G: 100, A: 255}}
+	for x, c := range want {
+		if px := got.RGBAAt(x, 0); px != c {
+			t.Errorf("pixel %d: got %v, want %v", x, px, c)
+		}
+	}
+}
@@ -0,0 +1,256 @@
+package lara
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ImageReviewEntry is one paragraph of an image, as exported for review and
+// read back once reviewed.
+type ImageReviewEntry struct {
+	// Image is the file name of the image the paragraph comes from.
+	Image          string
+	SourceLanguage string
+	TargetLanguage string
+	// ID numbers the paragraph within its image, starting at 1.
+	ID          string
+	Source      string
+	Translation string
+	Approved    bool
+}
+
+// TUID identifies the entry in a memory: the image file name and the
+// paragraph ID, e.g. "banner.png#2".
+func (e *ImageReviewEntry) TUID() string {
+	return e.Image + "#" + e.ID
+}
+
+// ReviewEntries turns the paragraphs into review entries for the
+// given image file and target language. None of them is approved.
+func (r *ImageTextResult) ReviewEntries(image, target string) []ImageReviewEntry {
+	entries := make([]ImageReviewEntry, len(r.Paragraphs))
+	for i, paragraph := range r.Paragraphs {
+		entries[i] = ImageReviewEntry{
+			Image:          filepath.Base(image),
+			SourceLanguage: r.SourceLanguage,
+			TargetLanguage: target,
+			ID:             strconv.Itoa(i + 1),
+			Source:         paragraph.Text,
+			Translation:    paragraph.Translation,
+		}
+	}
+	return entries
+}
+
+type xliffDocument struct {
+	XMLName xml.Name    `xml:"xliff"`
+	Version string      `xml:"version,attr"`
+	XMLNS   string      `xml:"xmlns,attr,omitempty"`
+	Files   []xliffFile `xml:"file"`
+}
+
+type xliffFile struct {
+	Original       string      `xml:"original,attr"`
+	SourceLanguage string      `xml:"source-language,attr"`
+	TargetLanguage string      `xml:"target-language,attr"`
+	Datatype       string      `xml:"datatype,attr"`
+	Units          []xliffUnit `xml:"body>trans-unit"`
+}
+
+type xliffUnit struct {
+	ID       string      `xml:"id,attr"`
+	Approved string      `xml:"approved,attr,omitempty"`
+	Source   string      `xml:"source"`
+	Target   xliffTarget `xml:"target"`
+}
+
+type xliffTarget struct {
+	State string `xml:"state,attr,omitempty"`
+	Text  string `xml:",chardata"`
+}
+
+// WriteImageReviewXLIFF writes entries as an XLIFF 1.2 document, with one
+// <file> per image and one <trans-unit> per paragraph.
+func WriteImageReviewXLIFF(w io.Writer, entries []ImageReviewEntry) error {
+	doc := xliffDocument{Version: "1.2", XMLNS: "urn:oasis:names:tc:xliff:document:1.2"}
+
+	files := map[[3]string]int{}
+	for _, entry := range entries {
+		key := [3]string{entry.Image, entry.SourceLanguage, entry.TargetLanguage}
+		i, ok := files[key]
+		if !ok {
+			i = len(doc.Files)
+			files[key] = i
+			doc.Files = append(doc.Files, xliffFile{
+				Original:       entry.Image,
+				SourceLanguage: entry.SourceLanguage,
+				TargetLanguage: entry.TargetLanguage,
+				Datatype:       "plaintext",
+			})
+		}
+
+		unit := xliffUnit{
+			ID:       entry.ID,
+			Approved: "no",
+			Source:   entry.Source,
+			Target:   xliffTarget{State: "translated", Text: entry.Translation},
+		}
+		if entry.Approved {
+			unit.Approved = "yes"
+			unit.Target.State = "final"
+		}
+		doc.Files[i].Units = append(doc.Files[i].Units, unit)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return fmt.Errorf("failed to write XLIFF: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// ReadImageReviewXLIFF reads back a document written by WriteImageReviewXLIFF.
+// A unit is approved when its approved attribute is "yes" or its target
+// state is "final" or "signed-off".
+func ReadImageReviewXLIFF(r io.Reader) ([]ImageReviewEntry, error) {
+	var doc xliffDocument
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("%w: invalid XLIFF: %v", ErrValidation, err)
+	}
+
+	var entries []ImageReviewEntry
+	for _, file := range doc.Files {
+		for _, unit := range file.Units {
+			entries = append(entries, ImageReviewEntry{
+				Image:          file.Original,
+				SourceLanguage: file.SourceLanguage,
+				TargetLanguage: file.TargetLanguage,
+				ID:             unit.ID,
+				Source:         unit.Source,
+				Translation:    unit.Target.Text,
+				Approved:       unit.Approved == "yes" || unit.Target.State == "final" || unit.Target.State == "signed-off",
+			})
+		}
+	}
+	return entries, nil
+}
+
+var imageReviewCSVHeader = []string{"image", "id", "source_language", "target_language", "source", "translation", "approved"}
+
+// WriteImageReviewCSV writes entries as CSV with a header row. Reviewers
+// mark a row as approved by setting its approved column to "yes".
+func WriteImageReviewCSV(w io.Writer, entries []ImageReviewEntry) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(imageReviewCSVHeader); err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		approved := "no"
+		if entry.Approved {
+			approved = "yes"
+		}
+		record := []string{entry.Image, entry.ID, entry.SourceLanguage, entry.TargetLanguage, entry.Source, entry.Translation, approved}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// ReadImageReviewCSV reads back a file written by WriteImageReviewCSV. Columns
+// are matched by the names in the header row, so they may be reordered.
+// "yes", "y", "true", "1" and "x" mark a row as approved.
+func ReadImageReviewCSV(r io.Reader) ([]ImageReviewEntry, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid CSV: %v", ErrValidation, err)
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range imageReviewCSVHeader {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("%w: CSV has no %q column", ErrValidation, name)
+		}
+	}
+
+	var entries []ImageReviewEntry
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid CSV: %v", ErrValidation, err)
+		}
+
+		field := func(name string) string {
+			if i := columns[name]; i < len(record) {
+				return record[i]
+			}
+			return ""
+		}
+		var approved bool
+		switch strings.ToLower(strings.TrimSpace(field("approved"))) {
+		case "yes", "y", "true", "1", "x":
+			approved = true
+		}
+		entries = append(entries, ImageReviewEntry{
+			Image:          field("image"),
+			ID:             field("id"),
+			SourceLanguage: field("source_language"),
+			TargetLanguage: field("target_language"),
+			Source:         field("source"),
+			Translation:    field("translation"),
+			Approved:       approved,
+		})
+	}
+	return entries, nil
+}
+
+// AddImageReview adds the approved entries to the memory with the given id,
+// each under its TUID so that a later review of the same image updates the
+// units instead of duplicating them. Entries that are not approved, or have
+// no translation, are skipped. It stops at the first failure and returns the
+// imports started so far.
+func (m *MemoriesService) AddImageReview(ctx context.Context, id string, entries []ImageReviewEntry) ([]*MemoryImport, error) {
+	var imports []*MemoryImport
+	for _, entry := range entries {
+		if !entry.Approved || strings.TrimSpace(entry.Translation) == "" {
+			continue
+		}
+		if entry.SourceLanguage == "" || entry.TargetLanguage == "" {
+			return imports, fmt.Errorf("%w: entry %s has no source or target language", ErrValidation, entry.TUID())
+		}
+
+		memoryImport, err := m.AddTranslationEntry(ctx, MemoryEntry{
+			MemoryIDs:   []string{id},
+			Source:      entry.SourceLanguage,
+			Target:      entry.TargetLanguage,
+			Sentence:    entry.Source,
+			Translation: entry.Translation,
+			TUID:        entry.TUID(),
+		})
+		if err != nil {
+			return imports, err
+		}
+		imports = append(imports, memoryImport)
+	}
+	return imports, nil
+}
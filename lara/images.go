@@ -1,8 +1,18 @@
 package lara
 
 import (
+	"bytes"
+	"context"
 	"fmt"
+	"image"
+	_ "image/gif"  // register decoders for image.DecodeConfig
+	_ "image/jpeg" // register decoders for image.DecodeConfig
+	_ "image/png"  // register decoders for image.DecodeConfig
+	"mime"
+	"net/http"
 	"os"
+	"path/filepath"
+	"strings"
 )
 
 type ImagesService struct {
@@ -15,17 +25,43 @@ func newImagesService(client *Client) *ImagesService {
 	}
 }
 
-func (s *ImagesService) Translate(filePath, source *string, target string) ([]byte, error) {
-	return s.TranslateWithOptions(filePath, source, target, nil)
+func (s *ImagesService) Translate(filePath, source *string, target string) (*ImageTranslation, error) {
+	return s.TranslateCtx(context.Background(), filePath, source, target)
 }
 
-func (s *ImagesService) TranslateWithOptions(filePath, source *string, target string, options *ImageTranslateOptions) ([]byte, error) {
+func (s *ImagesService) TranslateCtx(ctx context.Context, filePath, source *string, target string) (*ImageTranslation, error) {
+	return s.TranslateWithOptionsCtx(ctx, filePath, source, target, nil)
+}
+
+func (s *ImagesService) TranslateWithOptions(filePath, source *string, target string, options *ImageTranslateOptions) (*ImageTranslation, error) {
+	return s.TranslateWithOptionsCtx(context.Background(), filePath, source, target, options)
+}
+
+func (s *ImagesService) TranslateWithOptionsCtx(ctx context.Context, filePath, source *string, target string, options *ImageTranslateOptions) (*ImageTranslation, error) {
 	file, err := os.Open(*filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open image file: %w", err)
 	}
 	defer file.Close()
 
+	return s.TranslateFromReader(ctx, NewUploadFile(file, filepath.Base(*filePath)), source, target, options)
+}
+
+// TranslateFromReader translates an image whose content does not need to be on disk.
+func (s *ImagesService) TranslateFromReader(ctx context.Context, image *UploadFile, source *string, target string, options *ImageTranslateOptions) (*ImageTranslation, error) {
+	if options != nil && options.OutputFormat != "" && !imageOutputFormats[options.OutputFormat] {
+		return nil, fmt.Errorf("%w: unsupported image output format %q", ErrValidation, options.OutputFormat)
+	}
+
+	var preprocessed *ImagePreprocessResult
+	if options != nil && options.Preprocess != nil {
+		var err error
+		image, preprocessed, err = preprocessUpload(image, options.Preprocess)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	body := map[string]interface{}{
 		"target": target,
 	}
@@ -51,6 +87,9 @@ func (s *ImagesService) TranslateWithOptions(filePath, source *string, target st
 		if model != "" {
 			body["model"] = string(model)
 		}
+		if options.OutputFormat != "" {
+			body["output_format"] = string(options.OutputFormat)
+		}
 	}
 
 	var headers map[string]string
@@ -58,29 +97,104 @@ func (s *ImagesService) TranslateWithOptions(filePath, source *string, target st
 		headers = map[string]string{"X-No-Trace": "true"}
 	}
 
-	files := map[string]*os.File{
-		"image": file,
+	files := map[string]*UploadFile{
+		"image": image,
 	}
 
-	result, err := s.client.PostRaw("/v2/images/translate", body, files, headers)
+	data, header, err := s.client.requestWithHeader(ctx, "POST", "/v2/images/translate", nil, body, files, headers)
 	if err != nil {
 		return nil, fmt.Errorf("failed to translate image: %w", err)
 	}
 
+	result := newImageTranslation(data, header)
+	result.Preprocessed = preprocessed
 	return result, nil
 }
 
+// ImageTranslation is a translated image.
+type ImageTranslation struct {
+	Data []byte
+	// ContentType is the MIME type reported by the server, or detected from
+	// Data when the server did not send one.
+	ContentType string
+	// Width and Height are decoded from the image header. They are zero for
+	// formats the standard library cannot decode, such as WebP.
+	Width  int
+	Height int
+	// Metadata holds the X-Lara-* response headers, keyed by canonical name.
+	Metadata map[string]string
+	// Preprocessed reports the preprocessing applied before upload, if any.
+	Preprocessed *ImagePreprocessResult
+}
+
+func newImageTranslation(data []byte, header http.Header) *ImageTranslation {
+	result := &ImageTranslation{
+		Data:        data,
+		ContentType: header.Get("Content-Type"),
+		Metadata:    map[string]string{},
+	}
+	if mediaType, _, err := mime.ParseMediaType(result.ContentType); err == nil {
+		result.ContentType = mediaType
+	}
+	if result.ContentType == "" || result.ContentType == "application/octet-stream" {
+		result.ContentType = http.DetectContentType(data)
+	}
+
+	if config, _, err := image.DecodeConfig(bytes.NewReader(data)); err == nil {
+		result.Width = config.Width
+		result.Height = config.Height
+	}
+
+	for name, values := range header {
+		if strings.HasPrefix(name, "X-Lara-") && len(values) > 0 {
+			result.Metadata[name] = values[0]
+		}
+	}
+	return result
+}
+
+// Extension returns the file extension matching ContentType, including the
+// dot, or "" if it is not an image type.
+func (t *ImageTranslation) Extension() string {
+	if format, ok := imageFormatsByType[t.ContentType]; ok {
+		return format.Extension()
+	}
+	return ""
+}
+
 func (s *ImagesService) TranslateText(filePath, source *string, target string) (*ImageTextResult, error) {
-	return s.TranslateTextWithOptions(filePath, source, target, nil)
+	return s.TranslateTextCtx(context.Background(), filePath, source, target)
+}
+
+func (s *ImagesService) TranslateTextCtx(ctx context.Context, filePath, source *string, target string) (*ImageTextResult, error) {
+	return s.TranslateTextWithOptionsCtx(ctx, filePath, source, target, nil)
 }
 
 func (s *ImagesService) TranslateTextWithOptions(filePath, source *string, target string, options *ImageTextTranslateOptions) (*ImageTextResult, error) {
+	return s.TranslateTextWithOptionsCtx(context.Background(), filePath, source, target, options)
+}
+
+func (s *ImagesService) TranslateTextWithOptionsCtx(ctx context.Context, filePath, source *string, target string, options *ImageTextTranslateOptions) (*ImageTextResult, error) {
 	file, err := os.Open(*filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open image file: %w", err)
 	}
 	defer file.Close()
 
+	return s.TranslateTextFromReader(ctx, NewUploadFile(file, filepath.Base(*filePath)), source, target, options)
+}
+
+// TranslateTextFromReader extracts and translates the text of an image whose content does not need to be on disk.
+func (s *ImagesService) TranslateTextFromReader(ctx context.Context, image *UploadFile, source *string, target string, options *ImageTextTranslateOptions) (*ImageTextResult, error) {
+	var preprocessed *ImagePreprocessResult
+	if options != nil && options.Preprocess != nil {
+		var err error
+		image, preprocessed, err = preprocessUpload(image, options.Preprocess)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	body := map[string]interface{}{
 		"target": target,
 	}
@@ -109,15 +223,16 @@ func (s *ImagesService) TranslateTextWithOptions(filePath, source *string, targe
 		headers = map[string]string{"X-No-Trace": "true"}
 	}
 
-	files := map[string]*os.File{
-		"image": file,
+	files := map[string]*UploadFile{
+		"image": image,
 	}
 
 	var result ImageTextResult
-	err = s.client.Post("/v2/images/translate-text", body, files, headers, &result)
+	err := s.client.Post(ctx, "/v2/images/translate-text", body, files, headers, &result)
 	if err != nil {
 		return nil, fmt.Errorf("failed to translate image text: %w", err)
 	}
 
+	result.Preprocessed = preprocessed
 	return &result, nil
 }
@@ -0,0 +1,247 @@
+package lara
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Job is a long-running Lara operation: a document or audio translation, or
+// a memory or glossary import. It lets orchestration code track any of them
+// the same way. Jobs are safe for concurrent use.
+type Job interface {
+	// ID identifies the operation on the server.
+	ID() string
+	// Refresh fetches the current state of the operation.
+	Refresh(ctx context.Context) error
+	// Done reports whether the operation has finished, successfully or not.
+	Done() bool
+	// Err returns the reason the operation failed, or nil.
+	Err() error
+	// Wait polls until the operation finishes, ctx ends or the maximum wait
+	// elapses. It returns Err once the operation has finished.
+	Wait(ctx context.Context, options *WaitOptions) error
+	// Events delivers a JobEvent for every status transition observed by
+	// Refresh or Wait, and is closed once the operation has finished. If the
+	// receiver falls behind, the oldest undelivered events are dropped.
+	Events() <-chan JobEvent
+}
+
+// Statuses reported by jobs that only expose their progress, such as imports.
+const (
+	JobStatusRunning   = "running"
+	JobStatusCompleted = "completed"
+)
+
+// JobEvent describes a status transition of a Job.
+type JobEvent struct {
+	ID             string
+	Status         string
+	PreviousStatus string
+	// Progress is the completed fraction between 0 and 1, or -1 when unknown.
+	Progress float64
+	Time     time.Time
+}
+
+// WaitOptions configures how Job.Wait polls an operation.
+type WaitOptions struct {
+	// PollInterval is the delay before the first status check. Defaults to 2s.
+	PollInterval time.Duration
+	// BackoffFactor multiplies the delay after every check; values <= 1 poll at a fixed interval.
+	BackoffFactor float64
+	// MaxPollInterval caps the delay when backing off. Defaults to 30s.
+	MaxPollInterval time.Duration
+	// MaxWaitTime bounds the whole wait; zero waits until the context ends.
+	MaxWaitTime time.Duration
+	// OnProgress is called with the job after every status check.
+	OnProgress func(job Job)
+}
+
+// jobEventBuffer is the capacity of a job's event channel.
+const jobEventBuffer = 16
+
+// jobState holds the bookkeeping shared by every Job implementation. Its mutex
+// also guards the resource snapshot kept by the embedding job.
+type jobState struct {
+	id string
+
+	mu       sync.Mutex
+	status   string
+	progress float64
+	done     bool
+	err      error
+	events   chan JobEvent
+}
+
+func newJobState(id, status string, progress float64, done bool, err error) *jobState {
+	s := &jobState{
+		id:       id,
+		status:   status,
+		progress: progress,
+		done:     done,
+		err:      err,
+		events:   make(chan JobEvent, jobEventBuffer),
+	}
+	if done {
+		close(s.events)
+	}
+	return s
+}
+
+func (s *jobState) ID() string {
+	return s.id
+}
+
+// Status returns the last observed status.
+func (s *jobState) Status() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.status
+}
+
+// Progress returns the last observed completed fraction, or -1 when unknown.
+func (s *jobState) Progress() float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.progress
+}
+
+func (s *jobState) Done() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.done
+}
+
+func (s *jobState) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+func (s *jobState) Events() <-chan JobEvent {
+	return s.events
+}
+
+// update records a new observation; apply, if not nil, runs under the lock to
+// store the embedding job's resource snapshot.
+func (s *jobState) update(status string, progress float64, done bool, err error, apply func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.done {
+		return
+	}
+	if apply != nil {
+		apply()
+	}
+
+	previous := s.status
+	s.status = status
+	s.progress = progress
+	s.done = done
+	s.err = err
+
+	if status != previous {
+		s.emit(JobEvent{
+			ID:             s.id,
+			Status:         status,
+			PreviousStatus: previous,
+			Progress:       progress,
+			Time:           time.Now(),
+		})
+	}
+	if done {
+		close(s.events)
+	}
+}
+
+// emit sends event without blocking, dropping the oldest event when the buffer is full.
+func (s *jobState) emit(event JobEvent) {
+	for {
+		select {
+		case s.events <- event:
+			return
+		default:
+		}
+		select {
+		case <-s.events:
+		default:
+		}
+	}
+}
+
+// waitJob implements Job.Wait on top of the job's Refresh.
+func waitJob(ctx context.Context, job Job, state *jobState, options *WaitOptions) error {
+	if options == nil {
+		options = &WaitOptions{}
+	}
+
+	if !state.Done() {
+		p := poller{
+			interval:    options.PollInterval,
+			maxInterval: options.MaxPollInterval,
+			factor:      options.BackoffFactor,
+			maxWait:     options.MaxWaitTime,
+		}
+		err := p.run(ctx, state.ID(), state.Status(), func(ctx context.Context) (string, bool, error) {
+			if err := job.Refresh(ctx); err != nil {
+				return "", false, err
+			}
+			if options.OnProgress != nil {
+				options.OnProgress(job)
+			}
+			return state.Status(), state.Done(), nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return state.Err()
+}
+
+// ImportJob tracks a memory or glossary import.
+type ImportJob struct {
+	*jobState
+	fetch func(ctx context.Context, id string) (*Import, error)
+	imp   Import
+}
+
+func newImportJob(imp *Import, fetch func(ctx context.Context, id string) (*Import, error)) *ImportJob {
+	status, done := importStatus(imp)
+	return &ImportJob{
+		jobState: newJobState(imp.ID, status, imp.Progress, done, nil),
+		fetch:    fetch,
+		imp:      *imp,
+	}
+}
+
+// Import returns the last observed state of the import.
+func (j *ImportJob) Import() *Import {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	imp := j.imp
+	return &imp
+}
+
+func (j *ImportJob) Refresh(ctx context.Context) error {
+	imp, err := j.fetch(ctx, j.ID())
+	if err != nil {
+		return err
+	}
+
+	status, done := importStatus(imp)
+	j.update(status, imp.Progress, done, nil, func() { j.imp = *imp })
+	return nil
+}
+
+func (j *ImportJob) Wait(ctx context.Context, options *WaitOptions) error {
+	return waitJob(ctx, j, j.jobState, options)
+}
+
+func importStatus(imp *Import) (string, bool) {
+	if imp.Progress >= 1.0 {
+		return JobStatusCompleted, true
+	}
+	return JobStatusRunning, false
+}
@@ -0,0 +1,183 @@
+package lara
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// scriptedStep is what a scriptedJob observes on one Refresh.
+type scriptedStep struct {
+	status string
+	done   bool
+	err    error // fails the operation
+	fetch  error // fails the Refresh itself
+}
+
+// scriptedJob is a Job whose Refresh replays steps, repeating the last one.
+type scriptedJob struct {
+	*jobState
+	steps     []scriptedStep
+	refreshes int
+}
+
+func newScriptedJob(status string, steps ...scriptedStep) *scriptedJob {
+	return &scriptedJob{jobState: newJobState("job_1", status, -1, false, nil), steps: steps}
+}
+
+func (j *scriptedJob) Refresh(ctx context.Context) error {
+	step := j.steps[len(j.steps)-1]
+	if j.refreshes < len(j.steps) {
+		step = j.steps[j.refreshes]
+	}
+	j.refreshes++
+	if step.fetch != nil {
+		return step.fetch
+	}
+	j.update(step.status, -1, step.done, step.err, nil)
+	return nil
+}
+
+func (j *scriptedJob) Wait(ctx context.Context, options *WaitOptions) error {
+	return waitJob(ctx, j, j.jobState, options)
+}
+
+var fastPolling = &WaitOptions{PollInterval: time.Millisecond}
+
+// drain collects the events of a finished job, failing if the channel is not closed.
+func drain(t *testing.T, job Job) []string {
+	var transitions []string
+	for {
+		select {
+		case event, ok := <-job.Events():
+			if !ok {
+				return transitions
+			}
+			if event.ID != job.ID() {
+				t.Errorf("event for %q, want %q", event.ID, job.ID())
+			}
+			transitions = append(transitions, event.PreviousStatus+">"+event.Status)
+		case <-time.After(time.Second):
+			t.Fatal("the events channel was not closed")
+		}
+	}
+}
+
+func TestJobEventsOncePerTransition(t *testing.T) {
+	job := newScriptedJob("initialized",
+		scriptedStep{status: "analyzing"},
+		scriptedStep{status: "analyzing"},
+		scriptedStep{status: "translating"},
+		scriptedStep{status: "translating"},
+		scriptedStep{status: "translated", done: true},
+	)
+
+	if err := job.Wait(context.Background(), fastPolling); err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+
+	want := []string{"initialized>analyzing", "analyzing>translating", "translating>translated"}
+	if got := drain(t, job); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("events %v, want %v", got, want)
+	}
+	if job.refreshes != 5 {
+		t.Errorf("got %d refreshes, want 5", job.refreshes)
+	}
+
+	// Later observations are ignored once the job has finished.
+	job.update("translating", -1, false, nil, nil)
+	if !job.Done() || job.Status() != "translated" {
+		t.Errorf("a finished job changed to %s", job.Status())
+	}
+}
+
+func TestJobWaitReturnsFailure(t *testing.T) {
+	failure := errors.New("translation failed")
+	job := newScriptedJob("translating", scriptedStep{status: "error", done: true, err: failure})
+
+	if err := job.Wait(context.Background(), fastPolling); err != failure {
+		t.Fatalf("Wait: got %v, want %v", err, failure)
+	}
+	if got := drain(t, job); len(got) != 1 || got[0] != "translating>error" {
+		t.Errorf("events %v, want [translating>error]", got)
+	}
+}
+
+func TestJobWaitEndsOnRefreshError(t *testing.T) {
+	fetchErr := &LaraError{Status: 500, Type: "ServerError"}
+	job := newScriptedJob("initialized",
+		scriptedStep{status: "translating"},
+		scriptedStep{fetch: fetchErr},
+		scriptedStep{status: "translated", done: true},
+	)
+
+	err := job.Wait(context.Background(), fastPolling)
+	if !errors.Is(err, ErrServer) {
+		t.Fatalf("Wait: got %v, want the Refresh error", err)
+	}
+	if job.refreshes != 2 {
+		t.Errorf("got %d refreshes, want Wait to stop at the failed one", job.refreshes)
+	}
+	if job.Done() {
+		t.Error("the job is done after a failed Refresh")
+	}
+	select {
+	case event := <-job.Events():
+		if event.Status != "translating" {
+			t.Errorf("unexpected event %+v", event)
+		}
+	default:
+		t.Error("the first transition was not delivered")
+	}
+	select {
+	case _, ok := <-job.Events():
+		t.Errorf("unexpected event or close (open: %t) while the job is still running", ok)
+	default:
+	}
+}
+
+func TestJobWaitTimeout(t *testing.T) {
+	job := newScriptedJob("initialized", scriptedStep{status: "translating"})
+
+	err := job.Wait(context.Background(), &WaitOptions{PollInterval: time.Millisecond, MaxWaitTime: 20 * time.Millisecond})
+	var timeout *WaitTimeoutError
+	if !errors.As(err, &timeout) || !errors.Is(err, ErrTimeout) {
+		t.Fatalf("Wait: got %v, want a *WaitTimeoutError", err)
+	}
+	if timeout.ID != "job_1" || timeout.Status != "translating" {
+		t.Errorf("timeout for %s in %s, want job_1 in translating", timeout.ID, timeout.Status)
+	}
+}
+
+func TestJobEventsDropOldest(t *testing.T) {
+	job := newScriptedJob("s0")
+	for i := 1; i <= jobEventBuffer+5; i++ {
+		job.update(fmt.Sprintf("s%d", i), -1, false, nil, nil)
+	}
+	job.update("done", -1, true, nil, nil)
+
+	got := drain(t, job)
+	if len(got) != jobEventBuffer {
+		t.Fatalf("got %d events, want %d", len(got), jobEventBuffer)
+	}
+	if last := got[len(got)-1]; last != fmt.Sprintf("s%d>done", jobEventBuffer+5) {
+		t.Errorf("last event %s, want the final transition", last)
+	}
+}
+
+func TestJobAlreadyFinished(t *testing.T) {
+	failure := errors.New("failed before")
+	job := &scriptedJob{jobState: newJobState("job_1", "error", -1, true, failure)}
+
+	if err := job.Wait(context.Background(), fastPolling); err != failure {
+		t.Fatalf("Wait: got %v, want %v", err, failure)
+	}
+	if job.refreshes != 0 {
+		t.Errorf("got %d refreshes of a finished job", job.refreshes)
+	}
+	if got := drain(t, job); len(got) != 0 {
+		t.Errorf("events %v for a finished job", got)
+	}
+}
@@ -0,0 +1,344 @@
+package lara
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Kinds of translation recorded in a Journal.
+const (
+	JournalKindDocument = "document"
+	JournalKindAudio    = "audio"
+)
+
+// JournalStatus is the state of a journaled translation.
+type JournalStatus string
+
+const (
+	// JournalStatusPending marks a translation whose output has not been delivered yet.
+	JournalStatusPending JournalStatus = "pending"
+	// JournalStatusCompleted marks a translation whose output has been delivered.
+	JournalStatusCompleted JournalStatus = "completed"
+	// JournalStatusFailed marks a translation that failed on the server.
+	JournalStatusFailed JournalStatus = "failed"
+)
+
+// JournalEntry records one document or audio translation. ID is empty when
+// the process stopped after uploading the file but before the translation
+// was created; resuming creates it from S3Key without uploading again.
+type JournalEntry struct {
+	Key          string                 `json:"key"`
+	Kind         string                 `json:"kind"`
+	ID           string                 `json:"id,omitempty"`
+	S3Key        string                 `json:"s3key"`
+	Filename     string                 `json:"filename"`
+	Source       *string                `json:"source,omitempty"`
+	Target       string                 `json:"target"`
+	Options      map[string]interface{} `json:"options,omitempty"`
+	NoTrace      bool                   `json:"no_trace,omitempty"`
+	OutputFormat DocumentOutputFormat   `json:"output_format,omitempty"`
+	// Protected marks a password-protected document. Passwords are never
+	// written to the journal; see DocumentResumeOptions.Password.
+	Protected bool          `json:"protected,omitempty"`
+	Status    JournalStatus `json:"status"`
+	Error     string        `json:"error,omitempty"`
+	UpdatedAt time.Time     `json:"updated_at"`
+}
+
+// requestBody rebuilds the body that creates the translation.
+func (e *JournalEntry) requestBody() map[string]interface{} {
+	body := make(map[string]interface{}, len(e.Options)+3)
+	for k, v := range e.Options {
+		body[k] = v
+	}
+	body["s3key"] = e.S3Key
+	body["target"] = e.Target
+	if e.Source != nil {
+		body["source"] = *e.Source
+	}
+	return body
+}
+
+func (e *JournalEntry) headers() map[string]string {
+	if e.NoTrace {
+		return map[string]string{"X-No-Trace": "true"}
+	}
+	return nil
+}
+
+// Journal is an append-only on-disk log of document and audio translations.
+// When set in the upload options, every upload is recorded as soon as the
+// file reaches S3 and again once the translation is created, so that after a
+// crash or restart DocumentsService.Resume and AudioTranslator.Resume can
+// finish the pending translations without uploading or paying for them again.
+//
+// Writes are serialized across processes with a lock file, but a journal
+// should be resumed by a single worker at a time.
+type Journal struct {
+	path string
+}
+
+// OpenJournal opens the journal at path, creating the file and its parent
+// directory if they do not exist.
+func OpenJournal(path string) (*Journal, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create journal directory: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open journal: %w", err)
+	}
+	file.Close()
+
+	return &Journal{path: path}, nil
+}
+
+// Entries returns the latest state of every journaled translation, in the
+// order they were first recorded.
+func (j *Journal) Entries() ([]JournalEntry, error) {
+	data, err := os.ReadFile(j.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read journal: %w", err)
+	}
+
+	var order []string
+	latest := map[string]JournalEntry{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		// A crash can leave a line half written. Skipping it falls back to the
+		// previous state of the entry, which at worst is resumed once more.
+		var entry JournalEntry
+		if err := json.Unmarshal(line, &entry); err != nil || entry.Key == "" {
+			continue
+		}
+
+		if _, ok := latest[entry.Key]; !ok {
+			order = append(order, entry.Key)
+		}
+		latest[entry.Key] = entry
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read journal: %w", err)
+	}
+
+	entries := make([]JournalEntry, 0, len(order))
+	for _, key := range order {
+		entries = append(entries, latest[key])
+	}
+	return entries, nil
+}
+
+// Pending returns the translations of the given kind whose output has not
+// been delivered yet.
+func (j *Journal) Pending(kind string) ([]JournalEntry, error) {
+	entries, err := j.Entries()
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []JournalEntry
+	for _, entry := range entries {
+		if entry.Kind == kind && entry.Status == JournalStatusPending {
+			pending = append(pending, entry)
+		}
+	}
+	return pending, nil
+}
+
+// Compact rewrites the journal keeping only the pending translations.
+func (j *Journal) Compact() error {
+	unlock, err := lockPath(context.Background(), j.path+".lock")
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	entries, err := j.Entries()
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	for _, entry := range entries {
+		if entry.Status != JournalStatusPending {
+			continue
+		}
+		line, err := json.Marshal(entry)
+		if err != nil {
+			return fmt.Errorf("failed to marshal journal entry: %w", err)
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+
+	tmp := j.path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0600); err != nil {
+		return fmt.Errorf("failed to write journal: %w", err)
+	}
+	if err := os.Rename(tmp, j.path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to replace journal: %w", err)
+	}
+	return nil
+}
+
+// record appends the current state of entry, assigning it a key the first time.
+func (j *Journal) record(entry *JournalEntry) error {
+	if entry.Key == "" {
+		key := make([]byte, 8)
+		if _, err := rand.Read(key); err != nil {
+			return fmt.Errorf("failed to generate journal key: %w", err)
+		}
+		entry.Key = hex.EncodeToString(key)
+	}
+	entry.UpdatedAt = time.Now().UTC()
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal journal entry: %w", err)
+	}
+	line = append(line, '\n')
+
+	unlock, err := lockPath(context.Background(), j.path+".lock")
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	file, err := os.OpenFile(j.path, os.O_RDWR|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open journal: %w", err)
+	}
+	// Start on a new line if a crash left the previous one unterminated.
+	if info, err := file.Stat(); err == nil && info.Size() > 0 {
+		last := make([]byte, 1)
+		if _, err := file.ReadAt(last, info.Size()-1); err == nil && last[0] != '\n' {
+			line = append([]byte{'\n'}, line...)
+		}
+	}
+	if _, err := file.Write(line); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write journal: %w", err)
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write journal: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write journal: %w", err)
+	}
+	return nil
+}
+
+// finish marks the entry as completed, or as failed with cause.
+func (j *Journal) finish(entry *JournalEntry, cause error) error {
+	entry.Status = JournalStatusCompleted
+	entry.Error = ""
+	if cause != nil {
+		entry.Status = JournalStatusFailed
+		entry.Error = cause.Error()
+	}
+	return j.record(entry)
+}
+
+// JournalError is returned when a translation was created but could not be
+// recorded in the journal. The translation goes on on the server, but a
+// crash before its entry is written again would make Resume create it once
+// more; ID lets the caller wait for it and download it directly instead.
+type JournalError struct {
+	ID  string
+	Err error
+}
+
+func (e *JournalError) Error() string {
+	return fmt.Sprintf("translation %s was created but could not be journaled: %v", e.ID, e.Err)
+}
+
+func (e *JournalError) Unwrap() error {
+	return e.Err
+}
+
+// journaledReader marks a journal entry completed once the translated output
+// has been read to the end and closed.
+type journaledReader struct {
+	io.ReadCloser
+	journal *Journal
+	entry   *JournalEntry
+	eof     bool
+}
+
+func (r *journaledReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	if err == io.EOF {
+		r.eof = true
+	}
+	return n, err
+}
+
+func (r *journaledReader) Close() error {
+	err := r.ReadCloser.Close()
+	if r.eof && r.entry.Status == JournalStatusPending {
+		if finishErr := r.journal.finish(r.entry, nil); finishErr != nil && err == nil {
+			err = finishErr
+		}
+	}
+	return err
+}
+
+// ResumeHandler receives the outcome of a resumed translation. On success
+// content streams the translated output and must be closed by the handler;
+// if the translation failed, content is nil and err says why. Returning an
+// error stops resuming and leaves the entry pending.
+type ResumeHandler func(entry *JournalEntry, content io.ReadCloser, err error) error
+
+// resumeResult is how a journaled translation ended: content streams the
+// translated output, or failure says why the translation failed for good.
+type resumeResult struct {
+	content io.ReadCloser
+	failure error
+}
+
+// resumeFunc brings a journaled translation to an end. Its error reports a
+// problem that may go away on a later resume.
+type resumeFunc func(ctx context.Context, entry *JournalEntry) (*resumeResult, error)
+
+// resumePending runs resume for every pending entry of the given kind,
+// hands the outcome to handle and records it in the journal.
+func resumePending(ctx context.Context, journal *Journal, kind string, handle ResumeHandler, resume resumeFunc) error {
+	entries, err := journal.Pending(kind)
+	if err != nil {
+		return err
+	}
+
+	for i := range entries {
+		entry := &entries[i]
+
+		result, err := resume(ctx, entry)
+		if err != nil {
+			return fmt.Errorf("failed to resume %s: %w", entry.Filename, err)
+		}
+		if err := handle(entry, result.content, result.failure); err != nil {
+			return err
+		}
+		if err := journal.finish(entry, result.failure); err != nil {
+			return err
+		}
+	}
+	return nil
+}
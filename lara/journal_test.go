@@ -0,0 +1,107 @@
+package lara
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func openTestJournal(t *testing.T) *Journal {
+	journal, err := OpenJournal(filepath.Join(t.TempDir(), "state", "journal.jsonl"))
+	if err != nil {
+		t.Fatalf("OpenJournal: %v", err)
+	}
+	return journal
+}
+
+func TestJournalReplay(t *testing.T) {
+	journal := openTestJournal(t)
+
+	first := &JournalEntry{Kind: JournalKindDocument, S3Key: "uploads/1", Filename: "a.docx", Target: "it-IT", Status: JournalStatusPending}
+	second := &JournalEntry{Kind: JournalKindAudio, S3Key: "uploads/2", Filename: "b.mp3", Target: "fr-FR", Status: JournalStatusPending}
+	third := &JournalEntry{Kind: JournalKindDocument, S3Key: "uploads/3", Filename: "c.docx", Target: "de-DE", Status: JournalStatusPending}
+	for _, entry := range []*JournalEntry{first, second, third} {
+		if err := journal.record(entry); err != nil {
+			t.Fatalf("record: %v", err)
+		}
+	}
+	if first.Key == "" || first.Key == second.Key {
+		t.Fatalf("keys %q and %q are not unique", first.Key, second.Key)
+	}
+
+	first.ID = "doc_1"
+	if err := journal.record(first); err != nil {
+		t.Fatal(err)
+	}
+	if err := journal.finish(third, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// A crash can leave a half-written line; the next record starts a new one.
+	file, err := os.OpenFile(journal.path, os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.WriteString(`{"key":"` + first.Key + `","kind":"document","status":"compl`)
+	file.Close()
+	second.ID = "audio_2"
+	if err := journal.record(second); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := journal.Entries()
+	if err != nil {
+		t.Fatalf("Entries: %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("got %d entries, want 3", len(entries))
+	}
+	for i, want := range []struct {
+		filename, id string
+		status       JournalStatus
+	}{
+		{"a.docx", "doc_1", JournalStatusPending},
+		{"b.mp3", "audio_2", JournalStatusPending},
+		{"c.docx", "", JournalStatusCompleted},
+	} {
+		if got := entries[i]; got.Filename != want.filename || got.ID != want.id || got.Status != want.status {
+			t.Errorf("entry %d: got %s %q %s, want %s %q %s", i, got.Filename, got.ID, got.Status, want.filename, want.id, want.status)
+		}
+	}
+
+	pending, err := journal.Pending(JournalKindDocument)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 1 || pending[0].ID != "doc_1" {
+		t.Errorf("pending documents %+v, want only doc_1", pending)
+	}
+
+	if err := journal.Compact(); err != nil {
+		t.Fatalf("Compact: %v", err)
+	}
+	data, err := os.ReadFile(journal.path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Count(string(data), "\n"); lines != 2 || strings.Contains(string(data), "c.docx") {
+		t.Errorf("compacted journal:\n%s\nwant only the two pending entries", data)
+	}
+}
+
+func TestJournalFinishFailed(t *testing.T) {
+	journal := openTestJournal(t)
+	entry := &JournalEntry{Kind: JournalKindDocument, Filename: "a.docx", Status: JournalStatusPending}
+
+	if err := journal.finish(entry, os.ErrInvalid); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := journal.Entries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Status != JournalStatusFailed || entries[0].Error != os.ErrInvalid.Error() {
+		t.Errorf("got %+v, want one failed entry with the cause", entries)
+	}
+}
@@ -1,8 +1,11 @@
 package lara
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"time"
 )
 
@@ -17,8 +20,12 @@ func newMemoriesService(client *Client) *MemoriesService {
 }
 
 func (m *MemoriesService) List() ([]Memory, error) {
+	return m.ListCtx(context.Background())
+}
+
+func (m *MemoriesService) ListCtx(ctx context.Context) ([]Memory, error) {
 	var memories []Memory
-	err := m.client.Get("/v2/memories", nil, nil, &memories)
+	err := m.client.Get(ctx, "/v2/memories", nil, nil, &memories)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list memories: %w", err)
 	}
@@ -26,10 +33,18 @@ func (m *MemoriesService) List() ([]Memory, error) {
 }
 
 func (m *MemoriesService) Create(name string) (*Memory, error) {
-	return m.CreateWithExternalID(name, "")
+	return m.CreateCtx(context.Background(), name)
+}
+
+func (m *MemoriesService) CreateCtx(ctx context.Context, name string) (*Memory, error) {
+	return m.CreateWithExternalIDCtx(ctx, name, "")
 }
 
 func (m *MemoriesService) CreateWithExternalID(name string, externalID string) (*Memory, error) {
+	return m.CreateWithExternalIDCtx(context.Background(), name, externalID)
+}
+
+func (m *MemoriesService) CreateWithExternalIDCtx(ctx context.Context, name string, externalID string) (*Memory, error) {
 	body := map[string]interface{}{
 		"name": name,
 	}
@@ -38,7 +53,7 @@ func (m *MemoriesService) CreateWithExternalID(name string, externalID string) (
 	}
 
 	var memory Memory
-	err := m.client.Post("/v2/memories", body, nil, nil, &memory)
+	err := m.client.Post(ctx, "/v2/memories", body, nil, nil, &memory)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create memory: %w", err)
 	}
@@ -47,10 +62,14 @@ func (m *MemoriesService) CreateWithExternalID(name string, externalID string) (
 }
 
 func (m *MemoriesService) Get(id string) (*Memory, error) {
+	return m.GetCtx(context.Background(), id)
+}
+
+func (m *MemoriesService) GetCtx(ctx context.Context, id string) (*Memory, error) {
 	var memory Memory
-	err := m.client.Get(fmt.Sprintf("/v2/memories/%s", id), nil, nil, &memory)
+	err := m.client.Get(ctx, fmt.Sprintf("/v2/memories/%s", id), nil, nil, &memory)
 	if err != nil {
-		if laraErr, ok := err.(*LaraError); ok && laraErr.Status == 404 {
+		if errors.Is(err, ErrNotFound) {
 			return nil, nil
 		}
 		return nil, fmt.Errorf("failed to get memory: %w", err)
@@ -60,8 +79,12 @@ func (m *MemoriesService) Get(id string) (*Memory, error) {
 }
 
 func (m *MemoriesService) Delete(id string) (*Memory, error) {
+	return m.DeleteCtx(context.Background(), id)
+}
+
+func (m *MemoriesService) DeleteCtx(ctx context.Context, id string) (*Memory, error) {
 	var memory Memory
-	err := m.client.Delete(fmt.Sprintf("/v2/memories/%s", id), nil, nil, &memory)
+	err := m.client.Delete(ctx, fmt.Sprintf("/v2/memories/%s", id), nil, nil, &memory)
 	if err != nil {
 		return nil, fmt.Errorf("failed to delete memory: %w", err)
 	}
@@ -70,12 +93,16 @@ func (m *MemoriesService) Delete(id string) (*Memory, error) {
 }
 
 func (m *MemoriesService) Update(id, name string) (*Memory, error) {
+	return m.UpdateCtx(context.Background(), id, name)
+}
+
+func (m *MemoriesService) UpdateCtx(ctx context.Context, id, name string) (*Memory, error) {
 	body := map[string]interface{}{
 		"name": name,
 	}
 
 	var memory Memory
-	err := m.client.Put(fmt.Sprintf("/v2/memories/%s", id), body, nil, nil, &memory)
+	err := m.client.Put(ctx, fmt.Sprintf("/v2/memories/%s", id), body, nil, nil, &memory)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update memory: %w", err)
 	}
@@ -84,12 +111,16 @@ func (m *MemoriesService) Update(id, name string) (*Memory, error) {
 }
 
 func (m *MemoriesService) ConnectMultiple(ids []string) ([]Memory, error) {
+	return m.ConnectMultipleCtx(context.Background(), ids)
+}
+
+func (m *MemoriesService) ConnectMultipleCtx(ctx context.Context, ids []string) ([]Memory, error) {
 	body := map[string]interface{}{
 		"ids": ids,
 	}
 
 	var memories []Memory
-	err := m.client.Post("/v2/memories/connect", body, nil, nil, &memories)
+	err := m.client.Post(ctx, "/v2/memories/connect", body, nil, nil, &memories)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect memories: %w", err)
 	}
@@ -98,7 +129,11 @@ func (m *MemoriesService) ConnectMultiple(ids []string) ([]Memory, error) {
 }
 
 func (m *MemoriesService) Connect(id string) (*Memory, error) {
-	memories, err := m.ConnectMultiple([]string{id})
+	return m.ConnectCtx(context.Background(), id)
+}
+
+func (m *MemoriesService) ConnectCtx(ctx context.Context, id string) (*Memory, error) {
+	memories, err := m.ConnectMultipleCtx(ctx, []string{id})
 	if err != nil {
 		return nil, err
 	}
@@ -109,24 +144,45 @@ func (m *MemoriesService) Connect(id string) (*Memory, error) {
 }
 
 func (m *MemoriesService) ImportTmxFromPath(id, tmxPath string) (*MemoryImport, error) {
-	return m.ImportTmxFromPathWithCallback(id, tmxPath, false, "")
+	return m.ImportTmxFromPathCtx(context.Background(), id, tmxPath)
+}
+
+func (m *MemoriesService) ImportTmxFromPathCtx(ctx context.Context, id, tmxPath string) (*MemoryImport, error) {
+	return m.ImportTmxFromPathWithCallbackCtx(ctx, id, tmxPath, false, "")
 }
 
 func (m *MemoriesService) ImportTmxFromPathWithCallback(id, tmxPath string, gzip bool, callbackUrl string) (*MemoryImport, error) {
+	return m.ImportTmxFromPathWithCallbackCtx(context.Background(), id, tmxPath, gzip, callbackUrl)
+}
+
+func (m *MemoriesService) ImportTmxFromPathWithCallbackCtx(ctx context.Context, id, tmxPath string, gzip bool, callbackUrl string) (*MemoryImport, error) {
 	file, err := os.Open(tmxPath)
 	if err != nil {
 		return nil, err
 	}
 	defer file.Close()
 
-	return m.ImportTmxWithCallback(id, file, gzip, callbackUrl)
+	return m.ImportTmxWithCallbackCtx(ctx, id, file, gzip, callbackUrl)
 }
 
 func (m *MemoriesService) ImportTmx(id string, tmx *os.File) (*MemoryImport, error) {
-	return m.ImportTmxWithCallback(id, tmx, false, "")
+	return m.ImportTmxCtx(context.Background(), id, tmx)
+}
+
+func (m *MemoriesService) ImportTmxCtx(ctx context.Context, id string, tmx *os.File) (*MemoryImport, error) {
+	return m.ImportTmxWithCallbackCtx(ctx, id, tmx, false, "")
 }
 
 func (m *MemoriesService) ImportTmxWithCallback(id string, tmx *os.File, gzip bool, callbackUrl string) (*MemoryImport, error) {
+	return m.ImportTmxWithCallbackCtx(context.Background(), id, tmx, gzip, callbackUrl)
+}
+
+func (m *MemoriesService) ImportTmxWithCallbackCtx(ctx context.Context, id string, tmx *os.File, gzip bool, callbackUrl string) (*MemoryImport, error) {
+	return m.ImportTmxFromReader(ctx, id, NewUploadFile(tmx, filepath.Base(tmx.Name())), gzip, callbackUrl)
+}
+
+// ImportTmxFromReader imports TMX content that does not need to be on disk.
+func (m *MemoriesService) ImportTmxFromReader(ctx context.Context, id string, tmx *UploadFile, gzip bool, callbackUrl string) (*MemoryImport, error) {
 	body := map[string]string{}
 	if gzip {
 		body["compression"] = "gzip"
@@ -135,12 +191,12 @@ func (m *MemoriesService) ImportTmxWithCallback(id string, tmx *os.File, gzip bo
 		body["callback_url"] = callbackUrl
 	}
 
-	files := map[string]*os.File{
+	files := map[string]*UploadFile{
 		"tmx": tmx,
 	}
 
 	var memoryImport MemoryImport
-	err := m.client.Post(fmt.Sprintf("/v2/memories/%s/import", id), body, files, nil, &memoryImport)
+	err := m.client.Post(ctx, fmt.Sprintf("/v2/memories/%s/import", id), body, files, nil, &memoryImport)
 	if err != nil {
 		return nil, fmt.Errorf("failed to import TMX: %w", err)
 	}
@@ -149,8 +205,12 @@ func (m *MemoriesService) ImportTmxWithCallback(id string, tmx *os.File, gzip bo
 }
 
 func (m *MemoriesService) GetImportStatus(id string) (*MemoryImport, error) {
+	return m.GetImportStatusCtx(context.Background(), id)
+}
+
+func (m *MemoriesService) GetImportStatusCtx(ctx context.Context, id string) (*MemoryImport, error) {
 	var memoryImport MemoryImport
-	err := m.client.Get(fmt.Sprintf("/v2/memories/imports/%s", id), nil, nil, &memoryImport)
+	err := m.client.Get(ctx, fmt.Sprintf("/v2/memories/imports/%s", id), nil, nil, &memoryImport)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get import status: %w", err)
 	}
@@ -159,17 +219,25 @@ func (m *MemoriesService) GetImportStatus(id string) (*MemoryImport, error) {
 }
 
 func (m *MemoriesService) ExportAsync(id, callbackUrl string) (*MemoryExport, error) {
-	return m.ExportAsyncWithFormat(id, callbackUrl, "")
+	return m.ExportAsyncCtx(context.Background(), id, callbackUrl)
+}
+
+func (m *MemoriesService) ExportAsyncCtx(ctx context.Context, id, callbackUrl string) (*MemoryExport, error) {
+	return m.ExportAsyncWithFormatCtx(ctx, id, callbackUrl, "")
 }
 
 func (m *MemoriesService) ExportAsyncWithFormat(id, callbackUrl string, format MemoryExportFormat) (*MemoryExport, error) {
+	return m.ExportAsyncWithFormatCtx(context.Background(), id, callbackUrl, format)
+}
+
+func (m *MemoriesService) ExportAsyncWithFormatCtx(ctx context.Context, id, callbackUrl string, format MemoryExportFormat) (*MemoryExport, error) {
 	params := map[string]string{"callback_url": callbackUrl}
 	if format != "" {
 		params["format"] = string(format)
 	}
 
 	var memoryExport MemoryExport
-	err := m.client.Get(fmt.Sprintf("/v2/memories/%s/export/async", id), params, nil, &memoryExport)
+	err := m.client.Get(ctx, fmt.Sprintf("/v2/memories/%s/export/async", id), params, nil, &memoryExport)
 	if err != nil {
 		return nil, fmt.Errorf("failed to start memory export: %w", err)
 	}
@@ -177,191 +245,272 @@ func (m *MemoriesService) ExportAsyncWithFormat(id, callbackUrl string, format M
 	return &memoryExport, nil
 }
 
+// AddTranslationEntry adds entry to its memories. Adding an entry with the
+// TUID of an existing unit updates that unit.
+func (m *MemoriesService) AddTranslationEntry(ctx context.Context, entry MemoryEntry) (*MemoryImport, error) {
+	return m.updateEntry(ctx, "PUT", entry, len(entry.MemoryIDs) > 1)
+}
+
+// DeleteTranslationEntry deletes entry from its memories.
+func (m *MemoriesService) DeleteTranslationEntry(ctx context.Context, entry MemoryEntry) (*MemoryImport, error) {
+	return m.updateEntry(ctx, "DELETE", entry, len(entry.MemoryIDs) > 1)
+}
+
+// updateEntry adds or deletes entry, through the endpoint of its single
+// memory or, when multiple is set, the one that takes a list of memory IDs.
+func (m *MemoriesService) updateEntry(ctx context.Context, method string, entry MemoryEntry, multiple bool) (*MemoryImport, error) {
+	if len(entry.MemoryIDs) == 0 {
+		return nil, fmt.Errorf("%w: at least one memory ID is required", ErrValidation)
+	}
+
+	body := map[string]interface{}{
+		"source":      entry.Source,
+		"target":      entry.Target,
+		"sentence":    entry.Sentence,
+		"translation": entry.Translation,
+	}
+	if entry.TUID != "" {
+		body["tuid"] = entry.TUID
+	}
+	if entry.SentenceBefore != "" {
+		body["sentence_before"] = entry.SentenceBefore
+	}
+	if entry.SentenceAfter != "" {
+		body["sentence_after"] = entry.SentenceAfter
+	}
+
+	path := fmt.Sprintf("/v2/memories/%s/content", entry.MemoryIDs[0])
+	if multiple {
+		body["ids"] = entry.MemoryIDs
+		path = "/v2/memories/content"
+	}
+
+	var memoryImport MemoryImport
+	var err error
+	if method == "DELETE" {
+		err = m.client.Delete(ctx, path, body, entry.Headers, &memoryImport)
+	} else {
+		err = m.client.Put(ctx, path, body, nil, entry.Headers, &memoryImport)
+	}
+	if err != nil {
+		action := "add"
+		if method == "DELETE" {
+			action = "delete"
+		}
+		if multiple {
+			return nil, fmt.Errorf("failed to %s multiple translations: %w", action, err)
+		}
+		return nil, fmt.Errorf("failed to %s translation: %w", action, err)
+	}
+
+	return &memoryImport, nil
+}
+
 func (m *MemoriesService) AddTranslation(id, source, target, sentence, translation string) (*MemoryImport, error) {
-	return m.AddTranslationWithContextAndHeaders(id, source, target, sentence, translation, "", "", "", nil)
+	return m.AddTranslationCtx(context.Background(), id, source, target, sentence, translation)
+}
+
+func (m *MemoriesService) AddTranslationCtx(ctx context.Context, id, source, target, sentence, translation string) (*MemoryImport, error) {
+	return m.AddTranslationWithContextAndHeadersCtx(ctx, id, source, target, sentence, translation, "", "", "", nil)
 }
 
 func (m *MemoriesService) AddTranslationWithHeaders(id, source, target, sentence, translation string, headers map[string]string) (*MemoryImport, error) {
-	return m.AddTranslationWithContextAndHeaders(id, source, target, sentence, translation, "", "", "", headers)
+	return m.AddTranslationWithHeadersCtx(context.Background(), id, source, target, sentence, translation, headers)
+}
+
+func (m *MemoriesService) AddTranslationWithHeadersCtx(ctx context.Context, id, source, target, sentence, translation string, headers map[string]string) (*MemoryImport, error) {
+	return m.AddTranslationWithContextAndHeadersCtx(ctx, id, source, target, sentence, translation, "", "", "", headers)
 }
 
 func (m *MemoriesService) AddTranslationWithTuid(id, source, target, sentence, translation, tuid string) (*MemoryImport, error) {
-	return m.AddTranslationWithContextAndHeaders(id, source, target, sentence, translation, tuid, "", "", nil)
+	return m.AddTranslationWithTuidCtx(context.Background(), id, source, target, sentence, translation, tuid)
+}
+
+func (m *MemoriesService) AddTranslationWithTuidCtx(ctx context.Context, id, source, target, sentence, translation, tuid string) (*MemoryImport, error) {
+	return m.AddTranslationWithContextAndHeadersCtx(ctx, id, source, target, sentence, translation, tuid, "", "", nil)
 }
 
 func (m *MemoriesService) AddTranslationWithTuidAndHeaders(id, source, target, sentence, translation, tuid string, headers map[string]string) (*MemoryImport, error) {
-	return m.AddTranslationWithContextAndHeaders(id, source, target, sentence, translation, tuid, "", "", headers)
+	return m.AddTranslationWithTuidAndHeadersCtx(context.Background(), id, source, target, sentence, translation, tuid, headers)
+}
+
+func (m *MemoriesService) AddTranslationWithTuidAndHeadersCtx(ctx context.Context, id, source, target, sentence, translation, tuid string, headers map[string]string) (*MemoryImport, error) {
+	return m.AddTranslationWithContextAndHeadersCtx(ctx, id, source, target, sentence, translation, tuid, "", "", headers)
 }
 
 func (m *MemoriesService) AddTranslationWithContext(id, source, target, sentence, translation, tuid, sentenceBefore, sentenceAfter string) (*MemoryImport, error) {
-	return m.AddTranslationWithContextAndHeaders(id, source, target, sentence, translation, tuid, sentenceBefore, sentenceAfter, nil)
+	return m.AddTranslationWithContextCtx(context.Background(), id, source, target, sentence, translation, tuid, sentenceBefore, sentenceAfter)
+}
+
+func (m *MemoriesService) AddTranslationWithContextCtx(ctx context.Context, id, source, target, sentence, translation, tuid, sentenceBefore, sentenceAfter string) (*MemoryImport, error) {
+	return m.AddTranslationWithContextAndHeadersCtx(ctx, id, source, target, sentence, translation, tuid, sentenceBefore, sentenceAfter, nil)
 }
 
 func (m *MemoriesService) AddTranslationWithContextAndHeaders(id, source, target, sentence, translation, tuid, sentenceBefore, sentenceAfter string, headers map[string]string) (*MemoryImport, error) {
-	body := map[string]interface{}{
-		"source":      source,
-		"target":      target,
-		"sentence":    sentence,
-		"translation": translation,
-	}
-	if tuid != "" {
-		body["tuid"] = tuid
-	}
-	if sentenceBefore != "" {
-		body["sentence_before"] = sentenceBefore
-	}
-	if sentenceAfter != "" {
-		body["sentence_after"] = sentenceAfter
-	}
+	return m.AddTranslationWithContextAndHeadersCtx(context.Background(), id, source, target, sentence, translation, tuid, sentenceBefore, sentenceAfter, headers)
+}
 
-	var memoryImport MemoryImport
-	err := m.client.Put(fmt.Sprintf("/v2/memories/%s/content", id), body, nil, headers, &memoryImport)
-	if err != nil {
-		return nil, fmt.Errorf("failed to add translation: %w", err)
+func (m *MemoriesService) AddTranslationWithContextAndHeadersCtx(ctx context.Context, id, source, target, sentence, translation, tuid, sentenceBefore, sentenceAfter string, headers map[string]string) (*MemoryImport, error) {
+	entry := MemoryEntry{
+		MemoryIDs:      []string{id},
+		Source:         source,
+		Target:         target,
+		Sentence:       sentence,
+		Translation:    translation,
+		TUID:           tuid,
+		SentenceBefore: sentenceBefore,
+		SentenceAfter:  sentenceAfter,
+		Headers:        headers,
 	}
-
-	return &memoryImport, nil
+	return m.updateEntry(ctx, "PUT", entry, false)
 }
 
 func (m *MemoriesService) AddTranslationMultiple(ids []string, source, target, sentence, translation string) (*MemoryImport, error) {
-	return m.AddTranslationMultipleWithContextAndHeaders(ids, source, target, sentence, translation, "", "", "", nil)
+	return m.AddTranslationMultipleCtx(context.Background(), ids, source, target, sentence, translation)
+}
+
+func (m *MemoriesService) AddTranslationMultipleCtx(ctx context.Context, ids []string, source, target, sentence, translation string) (*MemoryImport, error) {
+	return m.AddTranslationMultipleWithContextAndHeadersCtx(ctx, ids, source, target, sentence, translation, "", "", "", nil)
 }
 
 func (m *MemoriesService) AddTranslationMultipleWithHeaders(ids []string, source, target, sentence, translation string, headers map[string]string) (*MemoryImport, error) {
-	return m.AddTranslationMultipleWithContextAndHeaders(ids, source, target, sentence, translation, "", "", "", headers)
+	return m.AddTranslationMultipleWithHeadersCtx(context.Background(), ids, source, target, sentence, translation, headers)
+}
+
+func (m *MemoriesService) AddTranslationMultipleWithHeadersCtx(ctx context.Context, ids []string, source, target, sentence, translation string, headers map[string]string) (*MemoryImport, error) {
+	return m.AddTranslationMultipleWithContextAndHeadersCtx(ctx, ids, source, target, sentence, translation, "", "", "", headers)
 }
 
 func (m *MemoriesService) AddTranslationMultipleWithTuid(ids []string, source, target, sentence, translation, tuid string) (*MemoryImport, error) {
-	return m.AddTranslationMultipleWithContextAndHeaders(ids, source, target, sentence, translation, tuid, "", "", nil)
+	return m.AddTranslationMultipleWithTuidCtx(context.Background(), ids, source, target, sentence, translation, tuid)
+}
+
+func (m *MemoriesService) AddTranslationMultipleWithTuidCtx(ctx context.Context, ids []string, source, target, sentence, translation, tuid string) (*MemoryImport, error) {
+	return m.AddTranslationMultipleWithContextAndHeadersCtx(ctx, ids, source, target, sentence, translation, tuid, "", "", nil)
 }
 
 func (m *MemoriesService) AddTranslationMultipleWithTuidAndHeaders(ids []string, source, target, sentence, translation, tuid string, headers map[string]string) (*MemoryImport, error) {
-	return m.AddTranslationMultipleWithContextAndHeaders(ids, source, target, sentence, translation, tuid, "", "", headers)
+	return m.AddTranslationMultipleWithTuidAndHeadersCtx(context.Background(), ids, source, target, sentence, translation, tuid, headers)
+}
+
+func (m *MemoriesService) AddTranslationMultipleWithTuidAndHeadersCtx(ctx context.Context, ids []string, source, target, sentence, translation, tuid string, headers map[string]string) (*MemoryImport, error) {
+	return m.AddTranslationMultipleWithContextAndHeadersCtx(ctx, ids, source, target, sentence, translation, tuid, "", "", headers)
 }
 
 func (m *MemoriesService) AddTranslationMultipleWithContext(ids []string, source, target, sentence, translation, tuid, sentenceBefore, sentenceAfter string) (*MemoryImport, error) {
-	return m.AddTranslationMultipleWithContextAndHeaders(ids, source, target, sentence, translation, tuid, sentenceBefore, sentenceAfter, nil)
+	return m.AddTranslationMultipleWithContextCtx(context.Background(), ids, source, target, sentence, translation, tuid, sentenceBefore, sentenceAfter)
+}
+
+func (m *MemoriesService) AddTranslationMultipleWithContextCtx(ctx context.Context, ids []string, source, target, sentence, translation, tuid, sentenceBefore, sentenceAfter string) (*MemoryImport, error) {
+	return m.AddTranslationMultipleWithContextAndHeadersCtx(ctx, ids, source, target, sentence, translation, tuid, sentenceBefore, sentenceAfter, nil)
 }
 
 func (m *MemoriesService) AddTranslationMultipleWithContextAndHeaders(ids []string, source, target, sentence, translation, tuid, sentenceBefore, sentenceAfter string, headers map[string]string) (*MemoryImport, error) {
-	body := map[string]interface{}{
-		"ids":         ids,
-		"source":      source,
-		"target":      target,
-		"sentence":    sentence,
-		"translation": translation,
-	}
-	if tuid != "" {
-		body["tuid"] = tuid
-	}
-	if sentenceBefore != "" {
-		body["sentence_before"] = sentenceBefore
-	}
-	if sentenceAfter != "" {
-		body["sentence_after"] = sentenceAfter
-	}
+	return m.AddTranslationMultipleWithContextAndHeadersCtx(context.Background(), ids, source, target, sentence, translation, tuid, sentenceBefore, sentenceAfter, headers)
+}
 
-	var memoryImport MemoryImport
-	err := m.client.Put("/v2/memories/content", body, nil, headers, &memoryImport)
-	if err != nil {
-		return nil, fmt.Errorf("failed to add multiple translations: %w", err)
+func (m *MemoriesService) AddTranslationMultipleWithContextAndHeadersCtx(ctx context.Context, ids []string, source, target, sentence, translation, tuid, sentenceBefore, sentenceAfter string, headers map[string]string) (*MemoryImport, error) {
+	entry := MemoryEntry{
+		MemoryIDs:      ids,
+		Source:         source,
+		Target:         target,
+		Sentence:       sentence,
+		Translation:    translation,
+		TUID:           tuid,
+		SentenceBefore: sentenceBefore,
+		SentenceAfter:  sentenceAfter,
+		Headers:        headers,
 	}
-
-	return &memoryImport, nil
+	return m.updateEntry(ctx, "PUT", entry, true)
 }
 
 func (m *MemoriesService) DeleteTranslation(id, source, target, sentence, translation string) (*MemoryImport, error) {
-	return m.DeleteTranslationWithContext(id, source, target, sentence, translation, "", "", "")
+	return m.DeleteTranslationCtx(context.Background(), id, source, target, sentence, translation)
+}
+
+func (m *MemoriesService) DeleteTranslationCtx(ctx context.Context, id, source, target, sentence, translation string) (*MemoryImport, error) {
+	return m.DeleteTranslationWithContextCtx(ctx, id, source, target, sentence, translation, "", "", "")
 }
 
 func (m *MemoriesService) DeleteTranslationWithTuid(id, source, target, sentence, translation, tuid string) (*MemoryImport, error) {
-	return m.DeleteTranslationWithContext(id, source, target, sentence, translation, tuid, "", "")
+	return m.DeleteTranslationWithTuidCtx(context.Background(), id, source, target, sentence, translation, tuid)
+}
+
+func (m *MemoriesService) DeleteTranslationWithTuidCtx(ctx context.Context, id, source, target, sentence, translation, tuid string) (*MemoryImport, error) {
+	return m.DeleteTranslationWithContextCtx(ctx, id, source, target, sentence, translation, tuid, "", "")
 }
 
 func (m *MemoriesService) DeleteTranslationWithContext(id, source, target, sentence, translation, tuid, sentenceBefore, sentenceAfter string) (*MemoryImport, error) {
-	body := map[string]interface{}{
-		"source":      source,
-		"target":      target,
-		"sentence":    sentence,
-		"translation": translation,
-	}
-	if tuid != "" {
-		body["tuid"] = tuid
-	}
-	if sentenceBefore != "" {
-		body["sentence_before"] = sentenceBefore
-	}
-	if sentenceAfter != "" {
-		body["sentence_after"] = sentenceAfter
-	}
+	return m.DeleteTranslationWithContextCtx(context.Background(), id, source, target, sentence, translation, tuid, sentenceBefore, sentenceAfter)
+}
 
-	var memoryImport MemoryImport
-	err := m.client.Delete(fmt.Sprintf("/v2/memories/%s/content", id), body, nil, &memoryImport)
-	if err != nil {
-		return nil, fmt.Errorf("failed to delete translation: %w", err)
+func (m *MemoriesService) DeleteTranslationWithContextCtx(ctx context.Context, id, source, target, sentence, translation, tuid, sentenceBefore, sentenceAfter string) (*MemoryImport, error) {
+	entry := MemoryEntry{
+		MemoryIDs:      []string{id},
+		Source:         source,
+		Target:         target,
+		Sentence:       sentence,
+		Translation:    translation,
+		TUID:           tuid,
+		SentenceBefore: sentenceBefore,
+		SentenceAfter:  sentenceAfter,
 	}
-
-	return &memoryImport, nil
+	return m.updateEntry(ctx, "DELETE", entry, false)
 }
 
 func (m *MemoriesService) DeleteTranslationMultiple(ids []string, source, target, sentence, translation string) (*MemoryImport, error) {
-	return m.DeleteTranslationMultipleWithContext(ids, source, target, sentence, translation, "", "", "")
+	return m.DeleteTranslationMultipleCtx(context.Background(), ids, source, target, sentence, translation)
+}
+
+func (m *MemoriesService) DeleteTranslationMultipleCtx(ctx context.Context, ids []string, source, target, sentence, translation string) (*MemoryImport, error) {
+	return m.DeleteTranslationMultipleWithContextCtx(ctx, ids, source, target, sentence, translation, "", "", "")
 }
 
 func (m *MemoriesService) DeleteTranslationMultipleWithTuid(ids []string, source, target, sentence, translation, tuid string) (*MemoryImport, error) {
-	return m.DeleteTranslationMultipleWithContext(ids, source, target, sentence, translation, tuid, "", "")
+	return m.DeleteTranslationMultipleWithTuidCtx(context.Background(), ids, source, target, sentence, translation, tuid)
+}
+
+func (m *MemoriesService) DeleteTranslationMultipleWithTuidCtx(ctx context.Context, ids []string, source, target, sentence, translation, tuid string) (*MemoryImport, error) {
+	return m.DeleteTranslationMultipleWithContextCtx(ctx, ids, source, target, sentence, translation, tuid, "", "")
 }
 
 func (m *MemoriesService) DeleteTranslationMultipleWithContext(ids []string, source, target, sentence, translation, tuid, sentenceBefore, sentenceAfter string) (*MemoryImport, error) {
-	body := map[string]interface{}{
-		"ids":         ids,
-		"source":      source,
-		"target":      target,
-		"sentence":    sentence,
-		"translation": translation,
-	}
-	if tuid != "" {
-		body["tuid"] = tuid
-	}
-	if sentenceBefore != "" {
-		body["sentence_before"] = sentenceBefore
-	}
-	if sentenceAfter != "" {
-		body["sentence_after"] = sentenceAfter
-	}
+	return m.DeleteTranslationMultipleWithContextCtx(context.Background(), ids, source, target, sentence, translation, tuid, sentenceBefore, sentenceAfter)
+}
 
-	var memoryImport MemoryImport
-	err := m.client.Delete("/v2/memories/content", body, nil, &memoryImport)
-	if err != nil {
-		return nil, fmt.Errorf("failed to delete multiple translations: %w", err)
+func (m *MemoriesService) DeleteTranslationMultipleWithContextCtx(ctx context.Context, ids []string, source, target, sentence, translation, tuid, sentenceBefore, sentenceAfter string) (*MemoryImport, error) {
+	entry := MemoryEntry{
+		MemoryIDs:      ids,
+		Source:         source,
+		Target:         target,
+		Sentence:       sentence,
+		Translation:    translation,
+		TUID:           tuid,
+		SentenceBefore: sentenceBefore,
+		SentenceAfter:  sentenceAfter,
 	}
-
-	return &memoryImport, nil
+	return m.updateEntry(ctx, "DELETE", entry, true)
 }
 
 func (m *MemoriesService) WaitForImport(memoryImport *MemoryImport, updateCallback func(*MemoryImport), maxWaitTime *time.Duration) (*MemoryImport, error) {
-	pollingInterval := 2 * time.Second
-	start := time.Now()
-	current := *memoryImport
-
-	for current.Progress < 1.0 {
-		if maxWaitTime != nil && time.Since(start) > *maxWaitTime {
-			return &current, fmt.Errorf("timeout waiting for import to complete")
-		}
-
-		time.Sleep(pollingInterval)
-
-		updated, err := m.GetImportStatus(current.ID)
-		if err != nil {
-			return &current, err
-		}
-
-		current = *updated
+	return m.WaitForImportCtx(context.Background(), memoryImport, updateCallback, maxWaitTime)
+}
 
-		if updateCallback != nil {
-			updateCallback(&current)
-		}
+func (m *MemoriesService) WaitForImportCtx(ctx context.Context, memoryImport *MemoryImport, updateCallback func(*MemoryImport), maxWaitTime *time.Duration) (*MemoryImport, error) {
+	job := m.ImportJob(memoryImport)
+	options := &WaitOptions{}
+	if maxWaitTime != nil {
+		options.MaxWaitTime = *maxWaitTime
+	}
+	if updateCallback != nil {
+		options.OnProgress = func(Job) { updateCallback(job.Import()) }
 	}
 
-	return &current, nil
+	err := job.Wait(ctx, options)
+	return job.Import(), err
+}
+
+// ImportJob returns a Job tracking the import.
+func (m *MemoriesService) ImportJob(memoryImport *MemoryImport) *ImportJob {
+	return newImportJob(memoryImport, m.GetImportStatusCtx)
 }
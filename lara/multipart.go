@@ -0,0 +1,380 @@
+package lara
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// formFile is a file field of a multipart request. open returns a fresh reader
+// positioned at the start of the content, so every attempt of a request
+// (token refresh, retries) sends exactly the same bytes.
+type formFile struct {
+	field       string
+	filename    string
+	contentType string
+	size        int64 // -1 when unknown
+	open        func() (io.ReadCloser, error)
+	cleanup     func()
+}
+
+// newFormFileFromSeeker reads r from its current offset on every open. It
+// returns nil if r cannot actually seek (a pipe behind an *os.File, say).
+func newFormFileFromSeeker(field, filename string, r io.ReadSeeker) *formFile {
+	offset, err := r.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil
+	}
+
+	size := int64(-1)
+	if end, err := r.Seek(0, io.SeekEnd); err == nil {
+		size = end - offset
+	}
+
+	var mu sync.Mutex
+	return &formFile{
+		field:       field,
+		filename:    filename,
+		contentType: mimeTypeOf(filename),
+		size:        size,
+		open: func() (io.ReadCloser, error) {
+			return &seekerSection{r: r, mu: &mu, pos: offset}, nil
+		},
+	}
+}
+
+// seekerSection reads a shared io.ReadSeeker from its own position. The lock
+// is only held for the duration of a Read, so a reader abandoned by the
+// transport cannot stall the readers of later attempts.
+type seekerSection struct {
+	r   io.ReadSeeker
+	mu  *sync.Mutex
+	pos int64
+}
+
+func (s *seekerSection) Read(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.r.Seek(s.pos, io.SeekStart); err != nil {
+		return 0, fmt.Errorf("failed to rewind file: %w", err)
+	}
+	n, err := s.r.Read(p)
+	s.pos += int64(n)
+	return n, err
+}
+
+func (s *seekerSection) Close() error {
+	return nil
+}
+
+// newFormFileFromPath opens the file at path anew for every attempt.
+func newFormFileFromPath(field, filename, path string) (*formFile, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &formFile{
+		field:       field,
+		filename:    filename,
+		contentType: mimeTypeOf(filename),
+		size:        info.Size(),
+		open: func() (io.ReadCloser, error) {
+			return os.Open(path)
+		},
+	}, nil
+}
+
+// newFormFileFromSpool copies r to a temporary file so it can be replayed
+// without holding the whole content in memory.
+func newFormFileFromSpool(field, filename string, r io.Reader) (*formFile, error) {
+	tmp, err := os.CreateTemp("", "lara-upload-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	cleanup := func() {
+		tmp.Close()
+		os.Remove(tmp.Name())
+	}
+
+	size, err := io.Copy(tmp, r)
+	if err != nil {
+		cleanup()
+		return nil, fmt.Errorf("failed to read file data: %w", err)
+	}
+
+	return &formFile{
+		field:       field,
+		filename:    filename,
+		contentType: mimeTypeOf(filename),
+		size:        size,
+		open: func() (io.ReadCloser, error) {
+			return io.NopCloser(io.NewSectionReader(tmp, 0, size)), nil
+		},
+		cleanup: cleanup,
+	}, nil
+}
+
+func mimeTypeOf(filename string) string {
+	if mimeType := mime.TypeByExtension(filepath.Ext(filename)); mimeType != "" {
+		return mimeType
+	}
+	return "application/octet-stream"
+}
+
+// multipartForm describes a multipart body independently of any single attempt.
+// The boundary is fixed and fields are sorted, so encoding it twice yields
+// byte-identical output and therefore the same Content-MD5.
+type multipartForm struct {
+	boundary string
+	fields   [][2]string
+	files    []*formFile
+	// filesLast puts the files after the plain fields, as S3 ignores any
+	// field that follows the file in a POST upload.
+	filesLast bool
+}
+
+func newMultipartForm(body interface{}, files []*formFile) (*multipartForm, error) {
+	form := &multipartForm{
+		boundary: multipart.NewWriter(io.Discard).Boundary(),
+		files:    files,
+	}
+
+	switch bodyMap := body.(type) {
+	case map[string]interface{}:
+		for key, value := range bodyMap {
+			switch v := value.(type) {
+			case string:
+				form.fields = append(form.fields, [2]string{key, v})
+			default:
+				jsonBytes, err := json.Marshal(v)
+				if err != nil {
+					return nil, fmt.Errorf("failed to marshal field %s: %w", key, err)
+				}
+				form.fields = append(form.fields, [2]string{key, string(jsonBytes)})
+			}
+		}
+	case map[string]string:
+		for key, value := range bodyMap {
+			form.fields = append(form.fields, [2]string{key, value})
+		}
+	}
+
+	sort.Slice(form.fields, func(i, j int) bool { return form.fields[i][0] < form.fields[j][0] })
+	sort.Slice(form.files, func(i, j int) bool { return form.files[i].field < form.files[j].field })
+
+	return form, nil
+}
+
+func (f *multipartForm) contentType() string {
+	return "multipart/form-data; boundary=" + f.boundary
+}
+
+// writeTo encodes the whole form, reading every file from the beginning.
+func (f *multipartForm) writeTo(w io.Writer) error {
+	return f.encode(w, false)
+}
+
+// encode writes the form to w. With skipContent set, file parts are written
+// without their content, which is enough to measure the envelope size.
+func (f *multipartForm) encode(w io.Writer, skipContent bool) error {
+	mw := multipart.NewWriter(w)
+	if err := mw.SetBoundary(f.boundary); err != nil {
+		return err
+	}
+
+	writeFields := func() error {
+		for _, field := range f.fields {
+			if err := mw.WriteField(field[0], field[1]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if f.filesLast {
+		if err := writeFields(); err != nil {
+			return err
+		}
+	}
+
+	for _, file := range f.files {
+		h := make(textproto.MIMEHeader)
+		h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`, file.field, file.filename))
+		h.Set("Content-Type", file.contentType)
+
+		fw, err := mw.CreatePart(h)
+		if err != nil {
+			return fmt.Errorf("failed to create form file: %w", err)
+		}
+		if skipContent {
+			continue
+		}
+
+		r, err := file.open()
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(fw, r)
+		r.Close()
+		if err != nil {
+			return fmt.Errorf("failed to copy file data: %w", err)
+		}
+	}
+
+	if !f.filesLast {
+		if err := writeFields(); err != nil {
+			return err
+		}
+	}
+
+	return mw.Close()
+}
+
+// length returns the encoded size of the form. When every file size is known
+// it is computed without reading any file content.
+func (f *multipartForm) length() (int64, error) {
+	var counter countingWriter
+	for _, file := range f.files {
+		if file.size < 0 {
+			if err := f.encode(&counter, false); err != nil {
+				return 0, err
+			}
+			return counter.n, nil
+		}
+	}
+
+	if err := f.encode(&counter, true); err != nil {
+		return 0, err
+	}
+	for _, file := range f.files {
+		counter.n += file.size
+	}
+	return counter.n, nil
+}
+
+// reader streams the encoded form through a pipe, so memory use does not
+// depend on the size of the files. The writing goroutine only ends once the
+// reader is drained or closed, so callers must close it if they do not send it.
+func (f *multipartForm) reader() io.ReadCloser {
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(f.writeTo(pw))
+	}()
+	return pr
+}
+
+func (f *multipartForm) close() {
+	for _, file := range f.files {
+		if file.cleanup != nil {
+			file.cleanup()
+		}
+	}
+}
+
+type countingWriter struct {
+	n int64
+	h hash.Hash
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	if w.h != nil {
+		w.h.Write(p)
+	}
+	return len(p), nil
+}
+
+// requestBody is the encoded-once description of a request payload, from
+// which a fresh reader can be produced for every attempt.
+type requestBody struct {
+	form        *multipartForm
+	json        []byte
+	contentType string
+
+	// Content-MD5 and length of the payload, computed on first use.
+	contentMD5 string
+	size       int64
+}
+
+func newRequestBody(body interface{}, files map[string]*UploadFile) (*requestBody, error) {
+	if len(files) > 0 {
+		formFiles := make([]*formFile, 0, len(files))
+		for field, f := range files {
+			formFile, err := f.formFile(field)
+			if err != nil {
+				for _, created := range formFiles {
+					if created.cleanup != nil {
+						created.cleanup()
+					}
+				}
+				return nil, err
+			}
+			formFiles = append(formFiles, formFile)
+		}
+
+		form, err := newMultipartForm(body, formFiles)
+		if err != nil {
+			return nil, err
+		}
+		return &requestBody{form: form, contentType: form.contentType()}, nil
+	}
+
+	if body != nil {
+		jsonBytes, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		return &requestBody{json: jsonBytes, contentType: "application/json"}, nil
+	}
+
+	return nil, nil
+}
+
+// digest returns the Content-MD5 and length of the payload. For multipart
+// bodies this takes a first pass over the files, hashing without buffering.
+func (b *requestBody) digest() (string, int64, error) {
+	if b.contentMD5 != "" {
+		return b.contentMD5, b.size, nil
+	}
+
+	if b.form == nil {
+		b.contentMD5 = fmt.Sprintf("%x", md5.Sum(b.json))
+		b.size = int64(len(b.json))
+		return b.contentMD5, b.size, nil
+	}
+
+	counter := countingWriter{h: md5.New()}
+	if err := b.form.writeTo(&counter); err != nil {
+		return "", 0, err
+	}
+	b.contentMD5 = fmt.Sprintf("%x", counter.h.Sum(nil))
+	b.size = counter.n
+	return b.contentMD5, b.size, nil
+}
+
+// reader returns a fresh reader over the payload for one attempt.
+func (b *requestBody) reader() io.ReadCloser {
+	if b.form != nil {
+		return b.form.reader()
+	}
+	return io.NopCloser(bytes.NewReader(b.json))
+}
+
+// close releases any temporary files backing the payload.
+func (b *requestBody) close() {
+	if b != nil && b.form != nil {
+		b.form.close()
+	}
+}
@@ -0,0 +1,173 @@
+package lara
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// onlyReader hides any io.Seeker, so the upload has to be spooled.
+type onlyReader struct {
+	io.Reader
+}
+
+// replayServer answers the first upload attempts with the given statuses and
+// records every body it receives along with its Content-MD5.
+type replayServer struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	statuses  []int
+	bodies    [][]byte
+	checksums []string
+}
+
+func newReplayServer(t *testing.T, statuses ...int) *replayServer {
+	s := &replayServer{statuses: statuses}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v2/auth" {
+			fmt.Fprintf(w, `{"token":%q}`, testJWT(time.Now().Add(time.Hour), 1))
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("reading upload body: %v", err)
+		}
+
+		s.mu.Lock()
+		s.bodies = append(s.bodies, body)
+		s.checksums = append(s.checksums, r.Header.Get("Content-MD5"))
+		status := http.StatusOK
+		if len(s.statuses) > 0 {
+			status, s.statuses = s.statuses[0], s.statuses[1:]
+		}
+		s.mu.Unlock()
+
+		w.WriteHeader(status)
+		if status == http.StatusOK {
+			fmt.Fprint(w, `{"id":"import"}`)
+		} else {
+			fmt.Fprint(w, `{"type":"Error","message":"try again"}`)
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func TestMultipartRetrySendsIdenticalBody(t *testing.T) {
+	content := strings.Repeat("<tu><tuv><seg>hello</seg></tuv></tu>\n", 2000)
+
+	tests := []struct {
+		name   string
+		reader func() io.Reader
+	}{
+		{"seekable", func() io.Reader { return strings.NewReader(content) }},
+		{"spooled", func() io.Reader { return onlyReader{strings.NewReader(content)} }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newReplayServer(t, http.StatusUnauthorized, http.StatusServiceUnavailable)
+			client := newClient(NewAccessKey("id", "secret"), server.URL, server.Client(), &RetryPolicy{
+				MaxAttempts:          3,
+				RetryableStatusCodes: []int{http.StatusServiceUnavailable},
+			})
+
+			body := map[string]interface{}{"compression": "gzip", "callback_url": "https://example.com/hook", "tags": []string{"a", "b"}}
+			files := map[string]*UploadFile{"tmx": NewUploadFile(tt.reader(), "memory.tmx")}
+			headers := map[string]string{IdempotencyKeyHeader: "key"}
+			if _, err := client.request(context.Background(), "POST", "/v2/memories/mem_1/import", nil, body, files, headers); err != nil {
+				t.Fatalf("request: %v", err)
+			}
+
+			// 401, then 503 on the token-refreshed attempt, then 200.
+			if len(server.bodies) != 3 {
+				t.Fatalf("got %d attempts, want 3", len(server.bodies))
+			}
+			if !bytes.Contains(server.bodies[0], []byte(content)) {
+				t.Fatal("the first attempt does not contain the file content")
+			}
+			for i, received := range server.bodies {
+				if !bytes.Equal(received, server.bodies[0]) {
+					t.Errorf("attempt %d sent a different body", i+1)
+				}
+				if want := fmt.Sprintf("%x", md5.Sum(received)); server.checksums[i] != want {
+					t.Errorf("attempt %d: Content-MD5 %q, want %q", i+1, server.checksums[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestMultipartRetryAfterAuthFailure(t *testing.T) {
+	content := strings.Repeat("<tu><tuv><seg>hello</seg></tuv></tu>\n", 4000)
+
+	tests := []struct {
+		name   string
+		reader func() io.Reader
+	}{
+		{"seekable", func() io.Reader { return strings.NewReader(content) }},
+		{"spooled", func() io.Reader { return onlyReader{strings.NewReader(content)} }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			auths := 0
+			var received []byte
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/v2/auth" {
+					mu.Lock()
+					auths++
+					first := auths == 1
+					mu.Unlock()
+					if first {
+						w.WriteHeader(http.StatusServiceUnavailable)
+						fmt.Fprint(w, `{"type":"ServiceUnavailable","message":"try again"}`)
+						return
+					}
+					fmt.Fprintf(w, `{"token":%q}`, testJWT(time.Now().Add(time.Hour), 1))
+					return
+				}
+				body, _ := io.ReadAll(r.Body)
+				mu.Lock()
+				received = body
+				mu.Unlock()
+				fmt.Fprint(w, `{"id":"import"}`)
+			}))
+			defer server.Close()
+
+			client := newClient(NewAccessKey("id", "secret"), server.URL, server.Client(), &RetryPolicy{
+				MaxAttempts:          2,
+				RetryableStatusCodes: []int{http.StatusServiceUnavailable},
+			})
+
+			// A reader left blocked by the failed attempt used to stall the retry forever.
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			files := map[string]*UploadFile{"tmx": NewUploadFile(tt.reader(), "memory.tmx")}
+			headers := map[string]string{IdempotencyKeyHeader: "key"}
+			if _, err := client.request(ctx, "POST", "/v2/memories/mem_1/import", nil, nil, files, headers); err != nil {
+				t.Fatalf("request: %v", err)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if auths != 2 {
+				t.Errorf("got %d calls to /v2/auth, want 2", auths)
+			}
+			if !bytes.Contains(received, []byte(content)) {
+				t.Error("the retried upload does not contain the file content")
+			}
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package lara
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// IdempotencyKeyHeader is the request header that marks a non-idempotent call
+// (such as a POST) as safe to retry.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// RetryPolicy controls how failed requests are retried. Only idempotent
+// methods (GET, HEAD, OPTIONS, PUT, DELETE) and requests carrying an
+// Idempotency-Key header are ever retried.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	// A value of 1 or less disables retries.
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry; it doubles on every attempt.
+	BaseDelay time.Duration
+	// MaxDelay caps the exponential backoff. A Retry-After header sent by the
+	// server takes precedence over the computed delay, but is capped by
+	// MaxDelay as well.
+	MaxDelay time.Duration
+	// Jitter is the fraction (0 to 1) of each delay that is randomized.
+	Jitter float64
+	// RetryableStatusCodes lists the HTTP status codes that trigger a retry.
+	RetryableStatusCodes []int
+	// RetryConnectionErrors enables retries on LaraConnectionError.
+	RetryConnectionErrors bool
+	// RetryTimeouts enables retries on LaraTimeoutError.
+	RetryTimeouts bool
+}
+
+// DefaultRetryPolicy returns the policy used when TranslatorOptions.RetryPolicy is nil.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:           3,
+		BaseDelay:             500 * time.Millisecond,
+		MaxDelay:              30 * time.Second,
+		Jitter:                0.2,
+		RetryableStatusCodes:  []int{429, 502, 503, 504},
+		RetryConnectionErrors: true,
+		RetryTimeouts:         true,
+	}
+}
+
+var (
+	jitterMu   sync.Mutex
+	jitterRand = rand.New(rand.NewSource(time.Now().UnixNano()))
+)
+
+// isIdempotent reports whether a request may be safely sent more than once.
+func isIdempotent(method string, headers map[string]string) bool {
+	switch strings.ToUpper(method) {
+	case "GET", "HEAD", "OPTIONS", "PUT", "DELETE":
+		return true
+	}
+	for k, v := range headers {
+		if strings.EqualFold(k, IdempotencyKeyHeader) && v != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// run calls fn until it succeeds, fails with a non-retryable error or the
+// policy runs out of attempts. Non-idempotent calls are attempted once.
+func (p *RetryPolicy) run(ctx context.Context, idempotent bool, fn func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if final, ok := err.(*finalError); ok {
+			return final.err
+		}
+		if err == nil || !idempotent || p == nil || attempt >= p.MaxAttempts || !p.isRetryable(err) {
+			return err
+		}
+		if serr := sleepContext(ctx, p.delay(attempt, err)); serr != nil {
+			return serr
+		}
+	}
+}
+
+// finalError marks an error that must not be retried regardless of its type,
+// e.g. a stream that failed after part of it was already delivered.
+type finalError struct {
+	err error
+}
+
+func (e *finalError) Error() string {
+	return e.err.Error()
+}
+
+func (p *RetryPolicy) isRetryable(err error) bool {
+	var laraErr *LaraError
+	if errors.As(err, &laraErr) {
+		return p.isRetryableStatus(laraErr.Status)
+	}
+	var s3Err *S3Error
+	if errors.As(err, &s3Err) {
+		return p.isRetryableStatus(s3Err.Status)
+	}
+	var connErr *LaraConnectionError
+	if errors.As(err, &connErr) {
+		return p.RetryConnectionErrors
+	}
+	var timeoutErr *LaraTimeoutError
+	if errors.As(err, &timeoutErr) {
+		return p.RetryTimeouts
+	}
+	return false
+}
+
+func (p *RetryPolicy) isRetryableStatus(status int) bool {
+	for _, code := range p.RetryableStatusCodes {
+		if code == status {
+			return true
+		}
+	}
+	return false
+}
+
+// delay computes the wait before the next attempt: exponential backoff with
+// jitter, or the server's Retry-After if that is longer, up to MaxDelay.
+func (p *RetryPolicy) delay(attempt int, err error) time.Duration {
+	d := p.BaseDelay
+	for i := 1; i < attempt && (p.MaxDelay <= 0 || d < p.MaxDelay); i++ {
+		d *= 2
+	}
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+
+	if p.Jitter > 0 && d > 0 {
+		jitter := p.Jitter
+		if jitter > 1 {
+			jitter = 1
+		}
+		jitterMu.Lock()
+		f := jitterRand.Float64()
+		jitterMu.Unlock()
+		d -= time.Duration(float64(d) * jitter * f)
+	}
+
+	if retryAfter := retryAfterOf(err); retryAfter > d {
+		d = retryAfter
+		if p.MaxDelay > 0 && d > p.MaxDelay {
+			d = p.MaxDelay
+		}
+	}
+	return d
+}
+
+func retryAfterOf(err error) time.Duration {
+	var laraErr *LaraError
+	if errors.As(err, &laraErr) {
+		return laraErr.RetryAfter
+	}
+	var s3Err *S3Error
+	if errors.As(err, &s3Err) {
+		return s3Err.RetryAfter
+	}
+	return 0
+}
+
+// parseRetryAfter parses a Retry-After header given either in seconds or as an HTTP date.
+func parseRetryAfter(value string) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
@@ -0,0 +1,194 @@
+package lara
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestIsIdempotent(t *testing.T) {
+	tests := []struct {
+		method  string
+		headers map[string]string
+		want    bool
+	}{
+		{"GET", nil, true},
+		{"head", nil, true},
+		{"OPTIONS", nil, true},
+		{"PUT", nil, true},
+		{"DELETE", nil, true},
+		{"POST", nil, false},
+		{"PATCH", nil, false},
+		{"POST", map[string]string{IdempotencyKeyHeader: "key"}, true},
+		{"POST", map[string]string{"idempotency-key": "key"}, true},
+		{"POST", map[string]string{IdempotencyKeyHeader: ""}, false},
+		{"POST", map[string]string{"X-Other": "key"}, false},
+	}
+
+	for _, tt := range tests {
+		if got := isIdempotent(tt.method, tt.headers); got != tt.want {
+			t.Errorf("isIdempotent(%s, %v) = %t, want %t", tt.method, tt.headers, got, tt.want)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		value    string
+		min, max time.Duration
+	}{
+		{"", 0, 0},
+		{"0", 0, 0},
+		{"5", 5 * time.Second, 5 * time.Second},
+		{" 120 ", 2 * time.Minute, 2 * time.Minute},
+		{"-3", 0, 0},
+		{"soon", 0, 0},
+		{time.Now().Add(time.Minute).UTC().Format(http.TimeFormat), 58 * time.Second, time.Minute},
+		{time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat), 0, 0},
+	}
+
+	for _, tt := range tests {
+		if got := parseRetryAfter(tt.value); got < tt.min || got > tt.max {
+			t.Errorf("parseRetryAfter(%q) = %s, want between %s and %s", tt.value, got, tt.min, tt.max)
+		}
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	policy := &RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	for attempt, want := range []time.Duration{100, 200, 400, 800, 1000, 1000} {
+		if got := policy.delay(attempt+1, nil); got != want*time.Millisecond {
+			t.Errorf("attempt %d: delay %s, want %s", attempt+1, got, want*time.Millisecond)
+		}
+	}
+
+	jittered := &RetryPolicy{BaseDelay: 400 * time.Millisecond, MaxDelay: time.Second, Jitter: 0.5}
+	for i := 0; i < 100; i++ {
+		if got := jittered.delay(1, nil); got < 200*time.Millisecond || got > 400*time.Millisecond {
+			t.Fatalf("jittered delay %s, want between 200ms and 400ms", got)
+		}
+	}
+
+	retryAfter := func(d time.Duration) error {
+		return fmt.Errorf("wrapped: %w", &LaraError{Status: 429, RetryAfter: d})
+	}
+	tests := []struct {
+		name string
+		err  error
+		want time.Duration
+	}{
+		{"retry-after wins over backoff", retryAfter(700 * time.Millisecond), 700 * time.Millisecond},
+		{"backoff wins over a shorter retry-after", retryAfter(10 * time.Millisecond), 100 * time.Millisecond},
+		{"retry-after capped by max delay", retryAfter(time.Hour), time.Second},
+		{"s3 retry-after", &S3Error{Status: 503, RetryAfter: 300 * time.Millisecond}, 300 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		if got := policy.delay(1, tt.err); got != tt.want {
+			t.Errorf("%s: delay %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRetryRun(t *testing.T) {
+	unavailable := &LaraError{Status: 503}
+	badRequest := &LaraError{Status: 400}
+	connection := &LaraConnectionError{Message: "reset"}
+	streamed := errors.New("stream broke")
+
+	policy := &RetryPolicy{
+		MaxAttempts:           3,
+		BaseDelay:             time.Millisecond,
+		RetryableStatusCodes:  []int{503},
+		RetryConnectionErrors: true,
+	}
+
+	tests := []struct {
+		name       string
+		policy     *RetryPolicy
+		idempotent bool
+		errs       []error // returned by successive attempts; nil after the last
+		attempts   int
+		want       error
+	}{
+		{"success", policy, true, nil, 1, nil},
+		{"recovers", policy, true, []error{unavailable, connection}, 3, nil},
+		{"exhausted", policy, true, []error{unavailable, unavailable, unavailable, unavailable}, 3, unavailable},
+		{"not retryable", policy, true, []error{badRequest}, 1, badRequest},
+		{"not idempotent", policy, false, []error{unavailable}, 1, unavailable},
+		{"final", policy, true, []error{&finalError{streamed}}, 1, streamed},
+		{"nil policy", nil, true, []error{unavailable}, 1, unavailable},
+		{"connection errors off", &RetryPolicy{MaxAttempts: 3}, true, []error{connection}, 1, connection},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			err := tt.policy.run(context.Background(), tt.idempotent, func() error {
+				attempts++
+				if attempts <= len(tt.errs) {
+					return tt.errs[attempts-1]
+				}
+				return nil
+			})
+			if err != tt.want {
+				t.Errorf("got error %v, want %v", err, tt.want)
+			}
+			if attempts != tt.attempts {
+				t.Errorf("got %d attempts, want %d", attempts, tt.attempts)
+			}
+		})
+	}
+}
+
+func TestRetryRunStopsWhenContextEnds(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	policy := &RetryPolicy{MaxAttempts: 5, BaseDelay: time.Hour, RetryableStatusCodes: []int{503}}
+	err := policy.run(ctx, true, func() error { return &LaraError{Status: 503} })
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, want context.DeadlineExceeded", err)
+	}
+}
+
+// TestStreamNotRetriedOnceStarted checks that a stream failing after its first
+// line reached the callback is not sent again, even for a retryable error.
+func TestStreamNotRetriedOnceStarted(t *testing.T) {
+	var calls int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v2/auth" {
+			fmt.Fprintf(w, `{"token":%q}`, testJWT(time.Now().Add(time.Hour), 1))
+			return
+		}
+		atomic.AddInt64(&calls, 1)
+		// Promise more than is sent, so the client sees the connection drop mid-stream.
+		w.Header().Set("Content-Length", "1000")
+		fmt.Fprint(w, "{\"partial\":true}\n")
+	}))
+	defer server.Close()
+
+	client := newClient(NewAccessKey("id", "secret"), server.URL, server.Client(), &RetryPolicy{
+		MaxAttempts:           3,
+		BaseDelay:             time.Millisecond,
+		RetryConnectionErrors: true,
+		RetryTimeouts:         true,
+	})
+
+	var lines int
+	headers := map[string]string{IdempotencyKeyHeader: "key"}
+	err := client.PostAndGetStream(context.Background(), "/v2/translate", map[string]string{"q": "hi"}, headers, func([]byte) error {
+		lines++
+		return nil
+	})
+	if err == nil {
+		t.Fatal("the broken stream did not fail")
+	}
+	if calls := atomic.LoadInt64(&calls); calls != 1 || lines != 1 {
+		t.Errorf("got %d requests and %d lines, want 1 and 1", calls, lines)
+	}
+}
@@ -1,75 +1,130 @@
 package lara
 
 import (
-	"bytes"
-	"fmt"
+	"context"
 	"io"
-	"mime/multipart"
 	"net/http"
-	"os"
 )
 
 type s3UploadFields map[string]string
 
 type S3Client struct {
-	httpClient *http.Client
+	httpClient  *http.Client
+	retryPolicy *RetryPolicy
 }
 
-func newS3Client() *S3Client {
+func newS3Client(httpClient *http.Client, retryPolicy *RetryPolicy) *S3Client {
 	return &S3Client{
-		httpClient: &http.Client{},
+		httpClient:  httpClient,
+		retryPolicy: retryPolicy,
 	}
 }
 
-func (s *S3Client) Upload(url string, fields s3UploadFields, filePath string) error {
-	file, err := os.Open(filePath)
+// Upload streams the file to a presigned S3 POST URL. Re-uploading to the same
+// key is harmless, so failed uploads are retried according to the retry policy.
+func (s *S3Client) Upload(ctx context.Context, url string, fields s3UploadFields, filePath string) error {
+	file, err := newFormFileFromPath("file", fields["key"], filePath)
 	if err != nil {
 		return err
 	}
-	defer file.Close()
+	file.contentType = "application/octet-stream"
 
-	var buf bytes.Buffer
-	writer := multipart.NewWriter(&buf)
+	return s.uploadFormFile(ctx, url, fields, file)
+}
 
-	for key, value := range fields {
-		writer.WriteField(key, value)
+// UploadReader is like Upload but reads the content from file instead of a path on disk.
+// The part is sent with file.ContentType, or application/octet-stream when it is empty.
+func (s *S3Client) UploadReader(ctx context.Context, url string, fields s3UploadFields, file *UploadFile) error {
+	formFile, err := file.formFile("file")
+	if err != nil {
+		return err
+	}
+	formFile.filename = fields["key"]
+	formFile.contentType = "application/octet-stream"
+	if file.ContentType != "" {
+		formFile.contentType = file.ContentType
 	}
+	if formFile.cleanup != nil {
+		defer formFile.cleanup()
+	}
+
+	return s.uploadFormFile(ctx, url, fields, formFile)
+}
 
-	part, err := writer.CreateFormFile("file", fields["key"])
+func (s *S3Client) uploadFormFile(ctx context.Context, url string, fields s3UploadFields, file *formFile) error {
+	form, err := newMultipartForm(map[string]string(fields), []*formFile{file})
 	if err != nil {
 		return err
 	}
+	form.filesLast = true
 
-	_, err = io.Copy(part, file)
+	// S3 rejects chunked uploads, so the exact length must be known up front.
+	size, err := form.length()
 	if err != nil {
 		return err
 	}
 
-	writer.Close()
+	return s.retryPolicy.run(ctx, true, func() error {
+		return s.upload(ctx, url, form, size)
+	})
+}
 
-	resp, err := s.httpClient.Post(url, writer.FormDataContentType(), &buf)
+func (s *S3Client) upload(ctx context.Context, url string, form *multipartForm, size int64) error {
+	req, err := http.NewRequestWithContext(ctx, "POST", url, nil)
 	if err != nil {
 		return err
 	}
+	req.Body = form.reader()
+	req.GetBody = func() (io.ReadCloser, error) { return form.reader(), nil }
+	req.ContentLength = size
+	req.Header.Set("Content-Type", form.contentType())
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return transportError(ctx, err)
+	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode >= 400 {
-		return fmt.Errorf("upload failed with status %d", resp.StatusCode)
+		return newS3Error("upload", resp)
 	}
 
 	return nil
 }
 
-func (s *S3Client) Download(url string) (io.ReadCloser, error) {
-	resp, err := s.httpClient.Get(url)
+func (s *S3Client) Download(ctx context.Context, url string) (io.ReadCloser, error) {
+	var body io.ReadCloser
+	err := s.retryPolicy.run(ctx, true, func() error {
+		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+		if err != nil {
+			return err
+		}
+
+		resp, err := s.httpClient.Do(req)
+		if err != nil {
+			return transportError(ctx, err)
+		}
+
+		if resp.StatusCode >= 400 {
+			resp.Body.Close()
+			return newS3Error("download", resp)
+		}
+
+		body = resp.Body
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	if resp.StatusCode >= 400 {
-		resp.Body.Close()
-		return nil, fmt.Errorf("download failed with status %d", resp.StatusCode)
-	}
+	return body, nil
+}
 
-	return resp.Body, nil
+func newS3Error(operation string, resp *http.Response) *S3Error {
+	return &S3Error{
+		Operation:  operation,
+		Status:     resp.StatusCode,
+		RequestID:  resp.Header.Get("X-Amz-Request-Id"),
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+	}
 }
@@ -1,6 +1,10 @@
 package lara
 
-import "fmt"
+import (
+	"context"
+	"errors"
+	"fmt"
+)
 
 type StyleguidesService struct {
 	client *Client
@@ -13,8 +17,12 @@ func newStyleguidesService(client *Client) *StyleguidesService {
 }
 
 func (s *StyleguidesService) List() ([]Styleguide, error) {
+	return s.ListCtx(context.Background())
+}
+
+func (s *StyleguidesService) ListCtx(ctx context.Context) ([]Styleguide, error) {
 	var styleguides []Styleguide
-	err := s.client.Get("/v2/styleguides", nil, nil, &styleguides)
+	err := s.client.Get(ctx, "/v2/styleguides", nil, nil, &styleguides)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list styleguides: %w", err)
 	}
@@ -22,13 +30,17 @@ func (s *StyleguidesService) List() ([]Styleguide, error) {
 }
 
 func (s *StyleguidesService) Create(name, content string) (*Styleguide, error) {
+	return s.CreateCtx(context.Background(), name, content)
+}
+
+func (s *StyleguidesService) CreateCtx(ctx context.Context, name, content string) (*Styleguide, error) {
 	body := map[string]interface{}{
 		"name":    name,
 		"content": content,
 	}
 
 	var styleguide Styleguide
-	err := s.client.Post("/v2/styleguides", body, nil, nil, &styleguide)
+	err := s.client.Post(ctx, "/v2/styleguides", body, nil, nil, &styleguide)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create styleguide: %w", err)
 	}
@@ -36,10 +48,14 @@ func (s *StyleguidesService) Create(name, content string) (*Styleguide, error) {
 }
 
 func (s *StyleguidesService) Get(id string) (*Styleguide, error) {
+	return s.GetCtx(context.Background(), id)
+}
+
+func (s *StyleguidesService) GetCtx(ctx context.Context, id string) (*Styleguide, error) {
 	var styleguide Styleguide
-	err := s.client.Get(fmt.Sprintf("/v2/styleguides/%s", id), nil, nil, &styleguide)
+	err := s.client.Get(ctx, fmt.Sprintf("/v2/styleguides/%s", id), nil, nil, &styleguide)
 	if err != nil {
-		if laraErr, ok := err.(*LaraError); ok && laraErr.Status == 404 {
+		if errors.Is(err, ErrNotFound) {
 			return nil, nil
 		}
 		return nil, fmt.Errorf("failed to get styleguide: %w", err)
@@ -48,8 +64,12 @@ func (s *StyleguidesService) Get(id string) (*Styleguide, error) {
 }
 
 func (s *StyleguidesService) Delete(id string) (*Styleguide, error) {
+	return s.DeleteCtx(context.Background(), id)
+}
+
+func (s *StyleguidesService) DeleteCtx(ctx context.Context, id string) (*Styleguide, error) {
 	var styleguide Styleguide
-	err := s.client.Delete(fmt.Sprintf("/v2/styleguides/%s", id), nil, nil, &styleguide)
+	err := s.client.Delete(ctx, fmt.Sprintf("/v2/styleguides/%s", id), nil, nil, &styleguide)
 	if err != nil {
 		return nil, fmt.Errorf("failed to delete styleguide: %w", err)
 	}
@@ -57,6 +77,10 @@ func (s *StyleguidesService) Delete(id string) (*Styleguide, error) {
 }
 
 func (s *StyleguidesService) Update(id string, name, content *string) (*Styleguide, error) {
+	return s.UpdateCtx(context.Background(), id, name, content)
+}
+
+func (s *StyleguidesService) UpdateCtx(ctx context.Context, id string, name, content *string) (*Styleguide, error) {
 	body := map[string]interface{}{}
 	if name != nil {
 		body["name"] = *name
@@ -66,7 +90,7 @@ func (s *StyleguidesService) Update(id string, name, content *string) (*Stylegui
 	}
 
 	var styleguide Styleguide
-	err := s.client.Put(fmt.Sprintf("/v2/styleguides/%s", id), body, nil, nil, &styleguide)
+	err := s.client.Put(ctx, fmt.Sprintf("/v2/styleguides/%s", id), body, nil, nil, &styleguide)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update styleguide: %w", err)
 	}
@@ -0,0 +1,145 @@
+package lara
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// TokenStore persists the session tokens so that they outlive the process and
+// can be shared between processes. The client loads tokens from the store
+// before signing in and saves them whenever they are renewed or rotated.
+type TokenStore interface {
+	// Load returns the stored tokens, or nil if the store is empty.
+	Load(ctx context.Context) (*AuthToken, error)
+	// Save replaces the stored tokens.
+	Save(ctx context.Context, tokens *AuthToken) error
+}
+
+// TokenLocker is implemented by token stores shared between processes. The
+// client holds the lock while it renews the session, so when many processes
+// find the token expired at once only one of them contacts the auth endpoint
+// and the others pick up its tokens from the store.
+type TokenLocker interface {
+	// Lock blocks until the lock is acquired or ctx ends.
+	Lock(ctx context.Context) (unlock func(), err error)
+}
+
+// MemoryTokenStore keeps tokens in memory. It lets several Translators in the
+// same process share one session.
+type MemoryTokenStore struct {
+	mu     sync.Mutex
+	tokens *AuthToken
+}
+
+// NewMemoryTokenStore creates an empty MemoryTokenStore
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return &MemoryTokenStore{}
+}
+
+// Load implements TokenStore
+func (s *MemoryTokenStore) Load(ctx context.Context) (*AuthToken, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.tokens == nil {
+		return nil, nil
+	}
+	tokens := *s.tokens
+	return &tokens, nil
+}
+
+// Save implements TokenStore
+func (s *MemoryTokenStore) Save(ctx context.Context, tokens *AuthToken) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	saved := *tokens
+	s.tokens = &saved
+	return nil
+}
+
+// FileTokenStore keeps tokens in a JSON file readable only by its owner.
+// Renewals are serialized across processes with an advisory lock on a
+// companion ".lock" file, so CLI invocations or serverless instances sharing
+// the file also share one session.
+type FileTokenStore struct {
+	path string
+}
+
+// NewFileTokenStore creates a FileTokenStore backed by the file at path.
+// The file and its parent directory are created on the first save.
+func NewFileTokenStore(path string) *FileTokenStore {
+	return &FileTokenStore{path: path}
+}
+
+type storedTokens struct {
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// Load implements TokenStore
+func (s *FileTokenStore) Load(ctx context.Context) (*AuthToken, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read token file: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var stored storedTokens
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, fmt.Errorf("failed to parse token file: %w", err)
+	}
+	return NewAuthToken(stored.Token, stored.RefreshToken), nil
+}
+
+// Save implements TokenStore. The file is replaced atomically, so readers
+// never observe a partially written token pair.
+func (s *FileTokenStore) Save(ctx context.Context, tokens *AuthToken) error {
+	data, err := json.Marshal(storedTokens{Token: tokens.Token, RefreshToken: tokens.RefreshToken})
+	if err != nil {
+		return fmt.Errorf("failed to marshal tokens: %w", err)
+	}
+
+	dir := filepath.Dir(s.path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create token directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, filepath.Base(s.path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("failed to create token file: %w", err)
+	}
+	tmpPath := tmp.Name()
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write token file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write token file: %w", err)
+	}
+	if err := os.Rename(tmpPath, s.path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to replace token file: %w", err)
+	}
+	return nil
+}
+
+// Lock implements TokenLocker
+func (s *FileTokenStore) Lock(ctx context.Context) (func(), error) {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create token directory: %w", err)
+	}
+	return lockPath(ctx, s.path+".lock")
+}
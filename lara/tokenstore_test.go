@@ -0,0 +1,133 @@
+package lara
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFileTokenStoreRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "nested", "tokens.json")
+	store := NewFileTokenStore(path)
+	ctx := context.Background()
+
+	if tokens, err := store.Load(ctx); err != nil || tokens != nil {
+		t.Fatalf("empty store: got %v, %v, want nil, nil", tokens, err)
+	}
+
+	for _, saved := range []*AuthToken{NewAuthToken("first", "refresh-1"), NewAuthToken("second", "refresh-2")} {
+		if err := store.Save(ctx, saved); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+		loaded, err := store.Load(ctx)
+		if err != nil {
+			t.Fatalf("Load: %v", err)
+		}
+		if loaded.Token != saved.Token || loaded.RefreshToken != saved.RefreshToken {
+			t.Errorf("loaded %+v, want %+v", loaded, saved)
+		}
+	}
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("the token directory holds %d files, want only the token file", len(entries))
+	}
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if perm := info.Mode().Perm(); perm != 0600 {
+			t.Errorf("token file mode %o, want 600", perm)
+		}
+	}
+
+	if err := os.WriteFile(path, []byte("{not json"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Load(ctx); err == nil {
+		t.Error("a corrupt token file loaded without error")
+	}
+}
+
+func TestFileTokenStoreLock(t *testing.T) {
+	store := NewFileTokenStore(filepath.Join(t.TempDir(), "tokens.json"))
+
+	unlock, err := store.Lock(context.Background())
+	if err != nil {
+		t.Fatalf("Lock: %v", err)
+	}
+
+	// A second lock on the file, as another process would take, has to wait.
+	ctx, cancel := context.WithTimeout(context.Background(), 150*time.Millisecond)
+	defer cancel()
+	if _, err := store.Lock(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("contended Lock: got %v, want context.DeadlineExceeded", err)
+	}
+
+	unlock()
+	relock, err := store.Lock(context.Background())
+	if err != nil {
+		t.Fatalf("Lock after unlock: %v", err)
+	}
+	relock()
+}
+
+// TestFileTokenStoreSingleRenewal starts concurrent renewals on several
+// clients sharing one FileTokenStore, as separate processes would: only one of
+// them may sign in, and the others must adopt its tokens.
+func TestFileTokenStoreSingleRenewal(t *testing.T) {
+	server := newStubServer(t)
+	store := NewFileTokenStore(filepath.Join(t.TempDir(), "tokens.json"))
+
+	var clients []*Client
+	for i := 0; i < 3; i++ {
+		client := newClient(NewAccessKey("id", "secret"), server.URL, server.Client(), &RetryPolicy{MaxAttempts: 1})
+		client.tokenStore = store
+		clients = append(clients, client)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 30*len(clients))
+	for _, client := range clients {
+		for i := 0; i < 30; i++ {
+			wg.Add(1)
+			go func(client *Client) {
+				defer wg.Done()
+				if err := client.renewToken(context.Background(), ""); err != nil {
+					errs <- err
+				}
+			}(client)
+		}
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+
+	if auths := atomic.LoadInt64(&server.auths); auths != 1 {
+		t.Errorf("got %d calls to /v2/auth, want 1", auths)
+	}
+	stored, err := store.Load(context.Background())
+	if err != nil || stored == nil {
+		t.Fatalf("Load: %v, %v", stored, err)
+	}
+	for i, client := range clients {
+		client.mu.Lock()
+		token := client.token
+		client.mu.Unlock()
+		if token != stored.Token {
+			t.Errorf("client %d did not adopt the stored token", i)
+		}
+	}
+}
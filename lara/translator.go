@@ -1,10 +1,15 @@
 package lara
 
 import (
+	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/url"
 )
 
+// Translator is safe for concurrent use; a single instance can be shared by many goroutines.
 type Translator struct {
 	client      *Client
 	Documents   *DocumentsService
@@ -17,23 +22,66 @@ type Translator struct {
 
 type TranslatorOptions struct {
 	ServerURL string
+	// RetryPolicy configures automatic retries of failed requests and S3 transfers.
+	// When nil, DefaultRetryPolicy is used; set MaxAttempts to 1 to disable retries.
+	RetryPolicy *RetryPolicy
+
+	// HTTPClient is used for Lara API calls. When nil, a client is built from Transport.
+	HTTPClient *http.Client
+	// Transport is used for Lara API calls when HTTPClient is nil. When both are nil,
+	// a pooled transport with the Default*Timeout values is used.
+	Transport http.RoundTripper
+	// S3HTTPClient is used for file uploads and downloads. When nil, a client is built from S3Transport.
+	S3HTTPClient *http.Client
+	// S3Transport is used for file uploads and downloads when S3HTTPClient is nil.
+	S3Transport http.RoundTripper
+	// Proxy selects the proxy for the default transports. When nil, the
+	// HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables are honored.
+	Proxy func(*http.Request) (*url.URL, error)
+	// TLSConfig is applied to the default transports, e.g. to trust a custom CA bundle.
+	TLSConfig *tls.Config
+
+	// TokenStore persists the session tokens. Tokens are loaded from it before
+	// signing in and saved back whenever they are renewed, so processes sharing
+	// a store also share one session. See MemoryTokenStore and FileTokenStore.
+	TokenStore TokenStore
+	// OnTokenRefresh is called with the new tokens whenever the client obtains
+	// a new session or the refresh token is rotated.
+	OnTokenRefresh func(tokens *AuthToken)
 }
 
-// NewTranslator creates a new Translator with any supported authentication method.
-// Accepts *Credentials (deprecated), *AccessKey, *UserCredentials, or *AuthToken.
-func NewTranslator(auth interface{}, options *TranslatorOptions) *Translator {
+// NewTranslator creates a new Translator with any Authenticator: *AccessKey,
+// *AuthToken, *Credentials (deprecated) or a custom implementation.
+func NewTranslator(auth Authenticator, options *TranslatorOptions) (*Translator, error) {
 	if auth == nil {
-		auth = NewCredentials("", "")
+		return nil, fmt.Errorf("%w: an authentication method is required", ErrAuthentication)
+	}
+	if options == nil {
+		options = &TranslatorOptions{}
+	}
+
+	if err := validateAuthenticator(auth, options.TokenStore != nil); err != nil {
+		return nil, err
 	}
 
 	serverURL := "https://api.laratranslate.com"
-	if options != nil && options.ServerURL != "" {
+	if options.ServerURL != "" {
 		serverURL = options.ServerURL
 	}
 
-	client := newClient(auth, serverURL)
+	retryPolicy := DefaultRetryPolicy()
+	if options.RetryPolicy != nil {
+		retryPolicy = options.RetryPolicy
+	}
+
+	httpClient := resolveHTTPClient(options.HTTPClient, options.Transport, options.Proxy, options.TLSConfig)
+	s3HTTPClient := resolveHTTPClient(options.S3HTTPClient, options.S3Transport, options.Proxy, options.TLSConfig)
+
+	client := newClient(auth, serverURL, httpClient, retryPolicy)
+	client.tokenStore = options.TokenStore
+	client.onTokenRefresh = options.OnTokenRefresh
 
-	s3Client := newS3Client()
+	s3Client := newS3Client(s3HTTPClient, retryPolicy)
 
 	return &Translator{
 		client:      client,
@@ -43,7 +91,32 @@ func NewTranslator(auth interface{}, options *TranslatorOptions) *Translator {
 		Audio:       newAudioTranslator(client, s3Client),
 		Images:      newImagesService(client),
 		Styleguides: newStyleguidesService(client),
+	}, nil
+}
+
+// validateAuthenticator rejects built-in authenticators that could never sign in,
+// so misconfiguration surfaces at construction rather than on the first request.
+// An empty AuthToken is accepted when the session can come from a token store.
+func validateAuthenticator(auth Authenticator, hasTokenStore bool) error {
+	switch a := auth.(type) {
+	case *AccessKey:
+		if a == nil || a.ID == "" || a.Secret == "" {
+			return fmt.Errorf("%w: access key ID and secret are required", ErrAuthentication)
+		}
+	case *Credentials:
+		if a == nil || a.AccessKey == nil {
+			return fmt.Errorf("%w: access key ID and secret are required", ErrAuthentication)
+		}
+		return validateAuthenticator(a.AccessKey, hasTokenStore)
+	case *AuthToken:
+		if a == nil {
+			return fmt.Errorf("%w: token or refresh token is required", ErrAuthentication)
+		}
+		if a.Token == "" && a.RefreshToken == "" && !hasTokenStore {
+			return fmt.Errorf("%w: token or refresh token is required", ErrAuthentication)
+		}
 	}
+	return nil
 }
 
 // Auto-called by Go's json package during unmarshaling
@@ -85,6 +158,11 @@ func (p *ProfanityDetectUnion) UnmarshalJSON(data []byte) error {
 }
 
 func (t *Translator) Translate(text interface{}, source string, target string, opts TranslateOptions) (*TextResult, error) {
+	return t.TranslateCtx(context.Background(), text, source, target, opts)
+}
+
+// TranslateCtx is like Translate but carries ctx down to the HTTP request.
+func (t *Translator) TranslateCtx(ctx context.Context, text interface{}, source string, target string, opts TranslateOptions) (*TextResult, error) {
 	body := make(map[string]interface{})
 	// Accept string, []string, or []TextBlock for text
 	switch v := text.(type) {
@@ -176,7 +254,7 @@ func (t *Translator) Translate(text interface{}, source string, target string, o
 
 	// Always use streaming; callback only invoked when reasoning is enabled
 	var lastResult *TextResult
-	err := t.client.PostAndGetStream("/v2/translate", body, headers, func(contentBytes []byte) error {
+	err := t.client.PostAndGetStream(ctx, "/v2/translate", body, headers, func(contentBytes []byte) error {
 		var result TextResult
 		if err := json.Unmarshal(contentBytes, &result); err != nil {
 			return fmt.Errorf("failed to unmarshal partial result: %w", err)
@@ -204,8 +282,12 @@ func (t *Translator) Translate(text interface{}, source string, target string, o
 }
 
 func (t *Translator) Languages() ([]string, error) {
+	return t.LanguagesCtx(context.Background())
+}
+
+func (t *Translator) LanguagesCtx(ctx context.Context) ([]string, error) {
 	var languages []string
-	err := t.client.Get("/v2/languages", nil, nil, &languages)
+	err := t.client.Get(ctx, "/v2/languages", nil, nil, &languages)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get languages: %w", err)
 	}
@@ -214,6 +296,10 @@ func (t *Translator) Languages() ([]string, error) {
 }
 
 func (t *Translator) QualityEstimation(source, target string, sentence, translation interface{}) (interface{}, error) {
+	return t.QualityEstimationCtx(context.Background(), source, target, sentence, translation)
+}
+
+func (t *Translator) QualityEstimationCtx(ctx context.Context, source, target string, sentence, translation interface{}) (interface{}, error) {
 	body := map[string]interface{}{
 		"source":      source,
 		"target":      target,
@@ -227,7 +313,7 @@ func (t *Translator) QualityEstimation(source, target string, sentence, translat
 			return nil, fmt.Errorf("translation must be a string when sentence is a string")
 		}
 		var single QualityEstimationResult
-		if err := t.client.Post("/v2/detect/quality-estimation", body, nil, nil, &single); err != nil {
+		if err := t.client.Post(ctx, "/v2/detect/quality-estimation", body, nil, nil, &single); err != nil {
 			return nil, fmt.Errorf("failed to estimate translation quality: %w", err)
 		}
 		return &single, nil
@@ -236,7 +322,7 @@ func (t *Translator) QualityEstimation(source, target string, sentence, translat
 			return nil, fmt.Errorf("translation must be a []string when sentence is a []string")
 		}
 		var batch []QualityEstimationResult
-		if err := t.client.Post("/v2/detect/quality-estimation", body, nil, nil, &batch); err != nil {
+		if err := t.client.Post(ctx, "/v2/detect/quality-estimation", body, nil, nil, &batch); err != nil {
 			return nil, fmt.Errorf("failed to estimate translation quality: %w", err)
 		}
 		return batch, nil
@@ -246,6 +332,10 @@ func (t *Translator) QualityEstimation(source, target string, sentence, translat
 }
 
 func (t *Translator) Detect(text interface{}, hint string, passlist []string) (*DetectResult, error) {
+	return t.DetectCtx(context.Background(), text, hint, passlist)
+}
+
+func (t *Translator) DetectCtx(ctx context.Context, text interface{}, hint string, passlist []string) (*DetectResult, error) {
 	body := map[string]interface{}{
 		"q": text,
 	}
@@ -259,7 +349,7 @@ func (t *Translator) Detect(text interface{}, hint string, passlist []string) (*
 	}
 
 	var result DetectResult
-	err := t.client.Post("/v2/detect/language", body, nil, nil, &result)
+	err := t.client.Post(ctx, "/v2/detect/language", body, nil, nil, &result)
 	if err != nil {
 		return nil, fmt.Errorf("failed to detect language: %w", err)
 	}
@@ -268,6 +358,10 @@ func (t *Translator) Detect(text interface{}, hint string, passlist []string) (*
 }
 
 func (t *Translator) DetectProfanities(text string, language string, contentType string) (*ProfanityDetectResult, error) {
+	return t.DetectProfanitiesCtx(context.Background(), text, language, contentType)
+}
+
+func (t *Translator) DetectProfanitiesCtx(ctx context.Context, text string, language string, contentType string) (*ProfanityDetectResult, error) {
 	body := map[string]interface{}{
 		"text":         text,
 		"language":     language,
@@ -275,7 +369,7 @@ func (t *Translator) DetectProfanities(text string, language string, contentType
 	}
 
 	var result ProfanityDetectResult
-	err := t.client.Post("/v2/detect/profanities", body, nil, nil, &result)
+	err := t.client.Post(ctx, "/v2/detect/profanities", body, nil, nil, &result)
 	if err != nil {
 		return nil, fmt.Errorf("failed to detect profanities: %w", err)
 	}
@@ -0,0 +1,55 @@
+package lara
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Default timeouts applied to the transports the SDK creates when no custom
+// http.Client or http.RoundTripper is supplied. There is deliberately no overall
+// request timeout, since document downloads and streamed translations can take
+// arbitrarily long; use a context deadline to bound a whole call.
+const (
+	DefaultConnectTimeout        = 10 * time.Second
+	DefaultTLSHandshakeTimeout   = 10 * time.Second
+	DefaultResponseHeaderTimeout = 2 * time.Minute
+)
+
+// newDefaultTransport builds a pooled transport with sensible timeouts.
+// A nil proxy means the standard HTTP_PROXY/HTTPS_PROXY/NO_PROXY variables apply.
+func newDefaultTransport(proxy func(*http.Request) (*url.URL, error), tlsConfig *tls.Config) *http.Transport {
+	if proxy == nil {
+		proxy = http.ProxyFromEnvironment
+	}
+
+	return &http.Transport{
+		Proxy: proxy,
+		DialContext: (&net.Dialer{
+			Timeout:   DefaultConnectTimeout,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		TLSClientConfig:       tlsConfig,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		MaxIdleConnsPerHost:   16,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   DefaultTLSHandshakeTimeout,
+		ResponseHeaderTimeout: DefaultResponseHeaderTimeout,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+}
+
+// resolveHTTPClient picks the caller's client, then the caller's transport,
+// and finally falls back to a default transport.
+func resolveHTTPClient(client *http.Client, transport http.RoundTripper, proxy func(*http.Request) (*url.URL, error), tlsConfig *tls.Config) *http.Client {
+	if client != nil {
+		return client
+	}
+	if transport == nil {
+		transport = newDefaultTransport(proxy, tlsConfig)
+	}
+	return &http.Client{Transport: transport}
+}
@@ -28,6 +28,24 @@ type Import struct {
 	Progress float64 `json:"progress"`
 }
 
+// MemoryEntry is a translation unit added to or deleted from one or more memories.
+type MemoryEntry struct {
+	// MemoryIDs lists the memories to update. Required.
+	MemoryIDs   []string
+	Source      string
+	Target      string
+	Sentence    string
+	Translation string
+	// TUID identifies the unit; adding an entry with the TUID of an existing
+	// unit updates it instead of creating a new one.
+	TUID string
+	// SentenceBefore and SentenceAfter give the context the sentence appears in.
+	SentenceBefore string
+	SentenceAfter  string
+	// Headers are added to the request.
+	Headers map[string]string
+}
+
 type MemoryImport = Import
 type GlossaryImport = Import
 
@@ -106,18 +124,48 @@ type DocumentOptions struct {
 }
 
 type DocumentDownloadOptions struct {
-	OutputFormat string
+	// OutputFormat converts the translation, e.g. to OutputFormatPdf; empty
+	// keeps the format of the original document.
+	OutputFormat DocumentOutputFormat
 }
 
 type DocumentUploadOptions struct {
 	DocumentOptions
 	ExtractionParams DocumentExtractionParams `json:"extraction_params,omitempty"`
 	Password         *string                  `json:"password,omitempty"`
+	// Journal, when set, records the upload so that it can be resumed after a restart.
+	Journal *Journal `json:"-"`
+}
+
+// DocumentWaitOptions configures how DocumentsService.Wait polls a document.
+type DocumentWaitOptions struct {
+	// PollInterval is the delay before the first status check. Defaults to 2s.
+	PollInterval time.Duration
+	// BackoffFactor multiplies the delay after every check; values <= 1 poll at a fixed interval.
+	BackoffFactor float64
+	// MaxPollInterval caps the delay when backing off. Defaults to 30s.
+	MaxPollInterval time.Duration
+	// MaxWaitTime bounds the whole wait. Defaults to 30 minutes; a negative
+	// value waits until the context ends.
+	MaxWaitTime time.Duration
+	// OnProgress is called with the document, including TranslatedChars and
+	// TotalChars, after every status check.
+	OnProgress func(document *Document)
+}
+
+// DocumentResumeOptions configures DocumentsService.ResumeWithOptions.
+type DocumentResumeOptions struct {
+	DocumentWaitOptions
+	// Password returns the password of a protected document, or nil. The
+	// journal never stores passwords, so without it a protected document
+	// whose translation had not been created yet cannot be resumed.
+	Password func(entry *JournalEntry) *string
 }
 
 type DocumentTranslateOptions struct {
 	DocumentUploadOptions
 	DocumentDownloadOptions
+	DocumentWaitOptions
 }
 
 type DocxExtractionParams struct {
@@ -125,7 +173,8 @@ type DocxExtractionParams struct {
 	AcceptRevisions *bool `json:"accept_revisions,omitempty"`
 }
 
-// Used to handle future extraction parameter types
+// DocumentExtractionParams is implemented by the extraction parameters of a
+// document format.
 type DocumentExtractionParams interface {
 	extractionParams()
 }
@@ -362,6 +411,44 @@ type AudioUploadOptions struct {
 	Style       TranslationStyle
 	NoTrace     *bool
 	VoiceGender VoiceGender
+	// Journal, when set, records the upload so that it can be resumed after a restart.
+	Journal *Journal
+	// Wait configures how TranslateWithOptions and TranslateMulti wait for the translation.
+	Wait *AudioWaitOptions
+	// Limits rejects files that are too large or too long, or use other codecs,
+	// before they are uploaded. When nil, only empty or malformed files are
+	// rejected.
+	Limits *AudioLimits
+	// SkipProbe uploads the file without inspecting it with ProbeAudio first.
+	SkipProbe bool
+}
+
+// AudioWaitOptions configures how AudioTranslator.Wait polls an audio translation.
+type AudioWaitOptions struct {
+	// PollInterval is the delay before the first status check. Defaults to 2s.
+	PollInterval time.Duration
+	// BackoffFactor multiplies the delay after every check; values <= 1 poll at a fixed interval.
+	BackoffFactor float64
+	// MaxPollInterval caps the delay when backing off. Defaults to 30s.
+	MaxPollInterval time.Duration
+	// MaxWaitTime bounds the whole wait. Defaults to 15 minutes; a negative
+	// value waits until the context ends.
+	MaxWaitTime time.Duration
+	// OnProgress is called after every status check.
+	OnProgress func(progress AudioProgress)
+}
+
+// AudioProgress reports the progress of an audio translation.
+type AudioProgress struct {
+	Audio *Audio
+	// TranslatedSeconds and TotalSeconds are zero until the server reports them.
+	TranslatedSeconds float64
+	TotalSeconds      float64
+	// Elapsed is the time spent waiting so far.
+	Elapsed time.Duration
+	// ETA estimates the time left from the translation rate observed while
+	// waiting, or is -1 when it cannot be estimated yet.
+	ETA time.Duration
 }
 
 type QualityEstimationResult struct {
@@ -386,6 +473,41 @@ type ImageTranslateOptions struct {
 	TextRemoval TextRemoval
 	Model       TextRemoval
 	NoTrace     *bool
+	// OutputFormat requests the format of the translated image; by default
+	// the server picks it.
+	OutputFormat ImageFormat
+	// Preprocess, when set, rotates, downscales and re-encodes the image
+	// before it is uploaded.
+	Preprocess *ImagePreprocessOptions
+}
+
+// ImageFormat is the encoding of an image.
+type ImageFormat string
+
+const (
+	ImageFormatPng  ImageFormat = "png"
+	ImageFormatJpeg ImageFormat = "jpeg"
+	ImageFormatWebp ImageFormat = "webp"
+)
+
+// Extension returns the file extension of the format, including the dot.
+func (f ImageFormat) Extension() string {
+	if f == ImageFormatJpeg {
+		return ".jpg"
+	}
+	return "." + string(f)
+}
+
+var imageOutputFormats = map[ImageFormat]bool{
+	ImageFormatPng:  true,
+	ImageFormatJpeg: true,
+	ImageFormatWebp: true,
+}
+
+var imageFormatsByType = map[string]ImageFormat{
+	"image/png":  ImageFormatPng,
+	"image/jpeg": ImageFormatJpeg,
+	"image/webp": ImageFormatWebp,
 }
 
 type ImageTextTranslateOptions struct {
@@ -394,6 +516,9 @@ type ImageTextTranslateOptions struct {
 	Style      TranslationStyle
 	NoTrace    *bool
 	Verbose    *bool
+	// Preprocess, when set, rotates, downscales and re-encodes the image
+	// before it is uploaded.
+	Preprocess *ImagePreprocessOptions
 }
 
 type ImageParagraph struct {
@@ -408,4 +533,6 @@ type ImageTextResult struct {
 	AdaptedTo      []string         `json:"adapted_to,omitempty"`
 	Glossaries     []string         `json:"glossaries,omitempty"`
 	Paragraphs     []ImageParagraph `json:"paragraphs"`
+	// Preprocessed reports the preprocessing applied before upload, if any.
+	Preprocessed *ImagePreprocessResult `json:"-"`
 }
@@ -0,0 +1,71 @@
+package lara
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+)
+
+// UploadFile is file content to upload that does not need to live on disk,
+// such as a blob storage object or an HTTP request body.
+type UploadFile struct {
+	// Reader provides the content. Readers that implement io.Seeker are
+	// streamed and rewound for retries; others are spooled to a temporary
+	// file first, so memory use stays flat either way.
+	Reader io.Reader
+	// Filename is reported to the API, which uses its extension to detect the format.
+	Filename string
+	// Size is the expected content length in bytes, or 0 if unknown. When set,
+	// content of a different length is rejected before anything is sent.
+	Size int64
+	// ContentType is the MIME type of the content. When empty it is inferred from Filename.
+	ContentType string
+}
+
+// NewUploadFile creates an UploadFile reading from r.
+func NewUploadFile(r io.Reader, filename string) *UploadFile {
+	return &UploadFile{
+		Reader:   r,
+		Filename: filename,
+	}
+}
+
+// NewUploadFileFromBytes creates an UploadFile over an in-memory buffer.
+func NewUploadFileFromBytes(data []byte, filename string) *UploadFile {
+	return &UploadFile{
+		Reader:   bytes.NewReader(data),
+		Filename: filename,
+		Size:     int64(len(data)),
+	}
+}
+
+// formFile turns the upload into a replayable multipart file field.
+func (u *UploadFile) formFile(field string) (*formFile, error) {
+	if u == nil || u.Reader == nil {
+		return nil, fmt.Errorf("upload file has no content")
+	}
+
+	var file *formFile
+	if seeker, ok := u.Reader.(io.ReadSeeker); ok {
+		file = newFormFileFromSeeker(field, u.Filename, seeker)
+	}
+	if file == nil {
+		var err error
+		file, err = newFormFileFromSpool(field, u.Filename, u.Reader)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if u.Size > 0 && file.size >= 0 && file.size != u.Size {
+		if file.cleanup != nil {
+			file.cleanup()
+		}
+		return nil, fmt.Errorf("upload file %s: expected %d bytes, read %d", u.Filename, u.Size, file.size)
+	}
+
+	if u.ContentType != "" {
+		file.contentType = u.ContentType
+	}
+	return file, nil
+}
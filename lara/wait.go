@@ -0,0 +1,73 @@
+package lara
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	defaultPollInterval    = 2 * time.Second
+	defaultMaxPollInterval = 30 * time.Second
+)
+
+// poller drives the status-polling loop shared by long-running operations.
+type poller struct {
+	interval    time.Duration
+	maxInterval time.Duration
+	factor      float64
+	maxWait     time.Duration
+}
+
+// pollFunc fetches the current state of an operation and reports its status
+// and whether it has finished.
+type pollFunc func(ctx context.Context) (status string, done bool, err error)
+
+// run calls poll until it reports done, ctx ends or the maximum wait elapses,
+// in which case a *WaitTimeoutError for the operation id is returned.
+func (p poller) run(ctx context.Context, id, status string, poll pollFunc) error {
+	interval := p.interval
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	maxInterval := p.maxInterval
+	if maxInterval <= 0 {
+		maxInterval = defaultMaxPollInterval
+	}
+	if maxInterval < interval {
+		maxInterval = interval
+	}
+
+	start := time.Now()
+	for {
+		delay := interval
+		if p.maxWait > 0 {
+			remaining := p.maxWait - time.Since(start)
+			if remaining <= 0 {
+				return &WaitTimeoutError{ID: id, Status: status, Waited: time.Since(start)}
+			}
+			if delay > remaining {
+				delay = remaining
+			}
+		}
+
+		if err := sleepContext(ctx, delay); err != nil {
+			return err
+		}
+
+		current, done, err := poll(ctx)
+		if err != nil {
+			return err
+		}
+		status = current
+		if done {
+			return nil
+		}
+
+		if p.factor > 1 {
+			interval = time.Duration(float64(interval) * p.factor)
+			if interval > maxInterval {
+				interval = maxInterval
+			}
+		}
+	}
+}